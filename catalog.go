@@ -0,0 +1,226 @@
+package gi18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultHistorySize 默认保留的历史快照数量
+const defaultHistorySize = 5
+
+// catalog 翻译目录，包含底层 go-i18n Bundle、已加载的语言及其加载来源
+//
+// catalog 发布后不再修改（localizers、chains 缓存除外），
+// 加载和重新加载总是构建新的 catalog 并整体替换
+type catalog struct {
+	bundle     *i18n.Bundle
+	supported  []string
+	sources    []loadSource
+	localizers sync.Map // map[string]*i18n.Localizer
+	chains     sync.Map // map[string][]*i18n.Localizer，各语言回退链上已加载语言的 Localizer
+
+	matcherOnce sync.Once
+	matcher     language.Matcher
+}
+
+// newCatalog 创建空的翻译目录
+func newCatalog(tag language.Tag) *catalog {
+	return &catalog{
+		bundle:    i18n.NewBundle(tag),
+		supported: make([]string, 0),
+	}
+}
+
+// unmarshalFuncs 各格式的解析器
+var unmarshalFuncs = map[string]i18n.UnmarshalFunc{
+	"json": json.Unmarshal,
+	"yaml": yaml.Unmarshal,
+	"yml":  yaml.Unmarshal,
+	"toml": toml.Unmarshal,
+}
+
+// withEmptyCache 返回共享翻译数据、但 Localizer 缓存为空的副本
+func (c *catalog) withEmptyCache() *catalog {
+	return &catalog{
+		bundle:    c.bundle,
+		supported: c.supported,
+		sources:   c.sources,
+	}
+}
+
+// addSupported 添加支持的语言
+func (c *catalog) addSupported(lang string) {
+	normalized := normalizeLanguageTag(lang)
+	for _, l := range c.supported {
+		if l == normalized {
+			return
+		}
+	}
+	c.supported = append(c.supported, normalized)
+}
+
+// hasDirSource 判断目录是否已通过 Load 加载
+func (c *catalog) hasDirSource(dir string) bool {
+	for _, src := range c.sources {
+		if src.dir != "" && src.dir == dir {
+			return true
+		}
+	}
+	return false
+}
+
+// messageSet 加载来源中单个语言的消息
+type messageSet struct {
+	lang     string
+	tag      language.Tag
+	messages []*i18n.Message
+}
+
+// addMessages 将消息加入翻译目录
+func (c *catalog) addMessages(set messageSet) error {
+	if err := c.bundle.AddMessages(set.tag, set.messages...); err != nil {
+		return fmt.Errorf("gi18n: failed to add messages for %s: %w", set.lang, err)
+	}
+	c.addSupported(set.lang)
+	return nil
+}
+
+// loadSource 可重放的加载来源
+//
+// 来源读取一次后缓存解析结果，构建新目录时直接复用；
+// 只有目录来源在重新加载时才会重新读取
+type loadSource struct {
+	dir  string // Load 加载的目录，其它来源为空
+	read func(b *Bundle) ([]messageSet, error)
+
+	sets   []messageSet // 解析结果
+	loaded bool         // 是否已读取
+}
+
+// build 按顺序合并加载来源，构建新的翻译目录，尚未读取的来源先读取
+func (b *Bundle) build(sources []loadSource) (*catalog, error) {
+	c := newCatalog(b.defaultTag)
+	built := make([]loadSource, len(sources))
+	for i, src := range sources {
+		if !src.loaded {
+			sets, err := src.read(b)
+			if err != nil {
+				return nil, err
+			}
+			src.sets, src.loaded = sets, true
+		}
+		for _, set := range src.sets {
+			if err := c.addMessages(set); err != nil {
+				return nil, err
+			}
+		}
+		built[i] = src
+	}
+	c.sources = built
+	return c, nil
+}
+
+// apply 在当前来源基础上追加新来源，构建并发布新的翻译目录
+// 已加载的来源使用缓存的解析结果，不会重新读取
+func (b *Bundle) apply(src loadSource) error {
+	b.loadMu.Lock()
+	defer b.loadMu.Unlock()
+
+	current := b.cat.Load()
+	sources := make([]loadSource, len(current.sources), len(current.sources)+1)
+	copy(sources, current.sources)
+
+	if src.dir != "" && current.hasDirSource(src.dir) {
+		// 同一目录只记录一次，重新读取该目录即可获得最新内容
+		markDirUnloaded(sources, src.dir)
+	} else {
+		sources = append(sources, src)
+	}
+
+	c, err := b.build(sources)
+	if err != nil {
+		return err
+	}
+	b.publish(c, false)
+	return nil
+}
+
+// markDirUnloaded 标记目录来源需要重新读取，dir 为空时标记全部目录来源
+func markDirUnloaded(sources []loadSource, dir string) {
+	for i := range sources {
+		if sources[i].dir != "" && (dir == "" || sources[i].dir == dir) {
+			sources[i].sets, sources[i].loaded = nil, false
+		}
+	}
+}
+
+// publish 发布新的翻译目录，snapshot 为 true 时将当前目录存入历史快照
+// 调用方需持有 loadMu
+func (b *Bundle) publish(c *catalog, snapshot bool) {
+	old := b.cat.Swap(c)
+	if !snapshot || old == nil || b.historySize <= 0 {
+		return
+	}
+	b.history = append(b.history, old.withEmptyCache())
+	if over := len(b.history) - b.historySize; over > 0 {
+		b.history = append(b.history[:0:0], b.history[over:]...)
+	}
+}
+
+// Reload 重新读取全部目录来源，构建新的翻译目录并原子替换
+//
+// 构建在旁路进行，并发的 T() 只会看到旧目录或新目录；
+// 任一来源加载失败时返回错误，当前翻译保持不变。
+// 成功后替换前的目录存入历史快照，供 Rollback 使用（Load 等加载方法不产生快照）
+func (b *Bundle) Reload() error {
+	b.loadMu.Lock()
+	defer b.loadMu.Unlock()
+
+	current := b.cat.Load().sources
+	sources := make([]loadSource, len(current))
+	copy(sources, current)
+	markDirUnloaded(sources, "")
+
+	c, err := b.build(sources)
+	if err != nil {
+		return err
+	}
+	b.publish(c, true)
+	return nil
+}
+
+// Rollback 回滚到上一次 Reload（包括 Watch 触发的重新加载）之前的翻译
+// 历史快照数量由 Config.HistorySize 控制，没有可用快照时返回 ErrNoSnapshot
+func (b *Bundle) Rollback() error {
+	b.loadMu.Lock()
+	defer b.loadMu.Unlock()
+
+	n := len(b.history)
+	if n == 0 {
+		return ErrNoSnapshot
+	}
+	prev := b.history[n-1]
+	b.history = b.history[:n-1]
+	b.cat.Store(prev.withEmptyCache())
+	return nil
+}
+
+// Snapshots 返回当前可回滚的快照数量
+func (b *Bundle) Snapshots() int {
+	b.loadMu.Lock()
+	defer b.loadMu.Unlock()
+	return len(b.history)
+}
+
+// clearLocalizerCache 清空 Localizer 和回退链缓存，回退配置变化时调用
+func (b *Bundle) clearLocalizerCache() {
+	b.loadMu.Lock()
+	defer b.loadMu.Unlock()
+	b.cat.Store(b.cat.Load().withEmptyCache())
+}
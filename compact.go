@@ -0,0 +1,210 @@
+package gi18n
+
+import (
+	"fmt"
+	"math"
+
+	"golang.org/x/text/language"
+)
+
+// UnitStyle 紧凑数字和单位的显示风格
+type UnitStyle int
+
+const (
+	UnitShort UnitStyle = iota // 1.2K、3 hr
+	UnitLong                   // 1.2 thousand、3 hours
+)
+
+// compactUnit 紧凑数字的数量级
+type compactUnit struct {
+	exp   int               // 10 的幂
+	short string            // 短格式，%s 为数字
+	long  map[string]string // 长格式，按复数形式，%s 为数字
+}
+
+// compactLocales 内置的紧凑数字格式（参考 CLDR），数量级按升序排列
+var compactLocales = map[string][]compactUnit{
+	"en": {
+		{3, "%sK", otherForm("%s thousand")},
+		{6, "%sM", otherForm("%s million")},
+		{9, "%sB", otherForm("%s billion")},
+		{12, "%sT", otherForm("%s trillion")},
+	},
+	"zh": {
+		{4, "%s万", otherForm("%s万")},
+		{8, "%s亿", otherForm("%s亿")},
+		{12, "%s万亿", otherForm("%s万亿")},
+	},
+	"zh-Hant": {
+		{4, "%s萬", otherForm("%s萬")},
+		{8, "%s億", otherForm("%s億")},
+		{12, "%s兆", otherForm("%s兆")},
+	},
+	"ja": {
+		{4, "%s万", otherForm("%s万")},
+		{8, "%s億", otherForm("%s億")},
+		{12, "%s兆", otherForm("%s兆")},
+	},
+	"ko": {
+		{3, "%s천", otherForm("%s천")},
+		{4, "%s만", otherForm("%s만")},
+		{8, "%s억", otherForm("%s억")},
+		{12, "%s조", otherForm("%s조")},
+	},
+	"de": {
+		{3, "%s\u00a0Tsd.", otherForm("%s Tausend")},
+		{6, "%s\u00a0Mio.", pluralForms("%s Million", "%s Millionen")},
+		{9, "%s\u00a0Mrd.", pluralForms("%s Milliarde", "%s Milliarden")},
+		{12, "%s\u00a0Bio.", pluralForms("%s Billion", "%s Billionen")},
+	},
+	"fr": {
+		{3, "%s\u00a0k", otherForm("%s mille")},
+		{6, "%s\u00a0M", pluralForms("%s million", "%s millions")},
+		{9, "%s\u00a0Md", pluralForms("%s milliard", "%s milliards")},
+		{12, "%s\u00a0Bn", pluralForms("%s billion", "%s billions")},
+	},
+	"es": {
+		{3, "%s\u00a0mil", otherForm("%s mil")},
+		{6, "%s\u00a0M", pluralForms("%s millón", "%s millones")},
+		{9, "%s\u00a0mil\u00a0M", otherForm("%s mil millones")},
+		{12, "%s\u00a0B", pluralForms("%s billón", "%s billones")},
+	},
+	"it": {
+		{3, "%s\u00a0K", otherForm("%s mila")},
+		{6, "%s\u00a0Mln", pluralForms("%s milione", "%s milioni")},
+		{9, "%s\u00a0Mrd", pluralForms("%s miliardo", "%s miliardi")},
+		{12, "%s\u00a0Bln", pluralForms("%s bilione", "%s bilioni")},
+	},
+	"pt": {
+		{3, "%s\u00a0mil", otherForm("%s mil")},
+		{6, "%s\u00a0mi", pluralForms("%s milhão", "%s milhões")},
+		{9, "%s\u00a0bi", pluralForms("%s bilhão", "%s bilhões")},
+		{12, "%s\u00a0tri", pluralForms("%s trilhão", "%s trilhões")},
+	},
+	"ru": {
+		{3, "%s\u00a0тыс.", russianForms("%s тысяча", "%s тысячи", "%s тысяч")},
+		{6, "%s\u00a0млн", russianForms("%s миллион", "%s миллиона", "%s миллионов")},
+		{9, "%s\u00a0млрд", russianForms("%s миллиард", "%s миллиарда", "%s миллиардов")},
+		{12, "%s\u00a0трлн", russianForms("%s триллион", "%s триллиона", "%s триллионов")},
+	},
+}
+
+// pickForm 按复数形式选择短语，缺少该形式时使用 other
+func pickForm(phrases map[string]string, form string) string {
+	if p, ok := phrases[form]; ok {
+		return p
+	}
+	return phrases["other"]
+}
+
+// compactDigits 紧凑数字保留的小数位数：小于 10 时保留 1 位，如 1.2K、12K
+func compactDigits(v float64) int {
+	if math.Abs(v) < 10 {
+		return 1
+	}
+	return 0
+}
+
+// formatCompact 按语言格式化紧凑数字，不足最小数量级时按普通数字格式化
+func formatCompact(tag language.Tag, v float64, style UnitStyle) string {
+	units, ok := lookupLocaleData(compactLocales, tag)
+	if !ok {
+		units = compactLocales["en"]
+	}
+
+	idx := -1
+	for i, u := range units {
+		if math.Abs(v) >= math.Pow10(u.exp) {
+			idx = i
+		}
+	}
+	if idx < 0 {
+		return formatNumber(tag, v, MaxFraction(0))
+	}
+
+	scaled := v / math.Pow10(units[idx].exp)
+	digits := compactDigits(scaled)
+	// 四舍五入后达到下一数量级时进位，如 999,999 -> 1M 而非 1000K
+	if idx+1 < len(units) {
+		next := math.Pow10(units[idx+1].exp - units[idx].exp)
+		if math.Abs(roundTo(scaled, digits)) >= next {
+			idx++
+			scaled = v / math.Pow10(units[idx].exp)
+			digits = compactDigits(scaled)
+		}
+	}
+
+	num := formatNumber(tag, scaled, MaxFraction(digits))
+	u := units[idx]
+	if style == UnitLong {
+		return fmt.Sprintf(pickForm(u.long, decimalPluralForm(tag, scaled, digits)), num)
+	}
+	return fmt.Sprintf(u.short, num)
+}
+
+// roundTo 四舍五入到指定小数位数
+func roundTo(v float64, digits int) float64 {
+	scale := math.Pow10(digits)
+	return math.Round(v*scale) / scale
+}
+
+// toFloat64 将模板参数中的数字转换为 float64
+func toFloat64(v interface{}) (float64, error) {
+	switch x := v.(type) {
+	case int:
+		return float64(x), nil
+	case int8:
+		return float64(x), nil
+	case int16:
+		return float64(x), nil
+	case int32:
+		return float64(x), nil
+	case int64:
+		return float64(x), nil
+	case uint:
+		return float64(x), nil
+	case uint8:
+		return float64(x), nil
+	case uint16:
+		return float64(x), nil
+	case uint32:
+		return float64(x), nil
+	case uint64:
+		return float64(x), nil
+	case float32:
+		return float64(x), nil
+	case float64:
+		return x, nil
+	}
+	return 0, fmt.Errorf("gi18n: unsupported number type %T", v)
+}
+
+// parseUnitStyle 解析模板函数中的风格名称，"long" 以外均为 UnitShort
+func parseUnitStyle(args []string) UnitStyle {
+	if len(args) > 0 && args[0] == "long" {
+		return UnitLong
+	}
+	return UnitShort
+}
+
+// FormatCompact 按语言格式化紧凑数字
+//
+//	bundle.FormatCompact("en", 1234, gi18n.UnitShort)    // "1.2K"
+//	bundle.FormatCompact("en", 1234, gi18n.UnitLong)     // "1.2 thousand"
+//	bundle.FormatCompact("zh-CN", 12345, gi18n.UnitShort) // "1.2万"
+//	bundle.FormatCompact("de", 1200000, gi18n.UnitShort) // "1,2 Mio."
+func (b *Bundle) FormatCompact(lang string, v float64, style UnitStyle) string {
+	return formatCompact(parseLanguageTag(lang), v, style)
+}
+
+// FormatCompact 按绑定的 Locale 格式化紧凑数字
+func (t *Translator) FormatCompact(v float64, style UnitStyle) string {
+	return formatCompact(t.locale.Tag(), v, style)
+}
+
+// ========== 全局函数 ==========
+
+// FormatCompact 按语言格式化紧凑数字（全局）
+func FormatCompact(lang string, v float64, style UnitStyle) string {
+	return Default().FormatCompact(lang, v, style)
+}
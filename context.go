@@ -2,12 +2,19 @@ package gi18n
 
 import (
 	"context"
+	"net"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
+
+	"golang.org/x/text/language"
 )
 
 type ctxKey struct{}
 
+type bundleCtxKey struct{}
+
 var langCtxKey = ctxKey{}
 
 // ========== Context 集成 ==========
@@ -21,12 +28,29 @@ func ContextWithLang(ctx context.Context, lang string) context.Context {
 }
 
 // LangFromContext 从 context 获取语言
-// 如果 context 中没有语言信息，返回全局默认语言
+// 如果 context 中没有语言信息，返回 context 中 Bundle 的当前语言（没有时使用全局实例）
 func LangFromContext(ctx context.Context) string {
 	if lang, ok := ctx.Value(langCtxKey).(string); ok {
 		return lang
 	}
-	return Default().GetLang()
+	return BundleFromContext(ctx).GetLang()
+}
+
+// ContextWithBundle 将 Bundle 注入到 context 中，供下游代码找到正确的实例
+//
+//	ctx := gi18n.ContextWithBundle(ctx, bundle)
+//	gi18n.BundleFromContext(ctx).T("hello", gi18n.WithContext(ctx))
+func ContextWithBundle(ctx context.Context, b *Bundle) context.Context {
+	return context.WithValue(ctx, bundleCtxKey{}, b)
+}
+
+// BundleFromContext 从 context 获取 Bundle
+// 如果 context 中没有 Bundle，返回全局实例
+func BundleFromContext(ctx context.Context) *Bundle {
+	if b, ok := ctx.Value(bundleCtxKey{}).(*Bundle); ok && b != nil {
+		return b
+	}
+	return Default()
 }
 
 // ========== HTTP 中间件 ==========
@@ -38,27 +62,72 @@ const (
 	SourceQuery  LangSource = iota // URL 参数 ?lang=zh-CN
 	SourceHeader                   // Accept-Language 头
 	SourceCookie                   // Cookie
+	SourcePath                     // URL 路径前缀 /zh-CN/products
+	SourceHost                     // 主机名 example.fr / 子域名 de.example.com
 )
 
 // MiddlewareConfig 中间件配置
 type MiddlewareConfig struct {
 	// 语言来源优先级，默认: Query > Cookie > Header
+	// 可通过 RegisterSource / HeaderSource / FormSource 加入自定义来源
+	// 使用 SourcePath 时，路径中的语言前缀会在调用下一个 handler 前去掉
 	Sources []LangSource
 	// URL 参数名，默认 "lang"
 	QueryParam string
 	// Cookie 名，默认 "lang"
 	CookieName string
-	// 默认语言，默认使用全局设置
+	// 主机名到语言的映射，用于 SourceHost，如 {"example.fr": "fr"}
+	HostLangs map[string]string
+	// SourceHost 是否从子域名提取语言，如 de.example.com -> de（仅识别已加载的语言）
+	HostSubdomain bool
+	// 默认语言，默认使用 Bundle 的当前语言
 	DefaultLang string
+	// 使用的 Bundle，默认为全局实例
+	// 中间件会将其注入 context，下游可通过 BundleFromContext / FromContext 获取
+	Bundle *Bundle
+	// 是否设置 Content-Language 响应头为检测到的语言，默认关闭
+	ContentLanguage bool
+	// 是否按使用的语言和 Locale 来源添加 Vary 响应头（Accept-Language / Cookie / 自定义请求头），避免缓存串语言，默认关闭
+	Vary bool
+
+	// 语言来自 URL 参数时是否写入 CookieName，使语言切换在后续请求中保持
+	PersistCookie bool
+	// Cookie 有效期（秒），0 表示会话 Cookie
+	CookieMaxAge int
+	// Cookie 路径，默认 "/"
+	CookiePath string
+	// Cookie 域名，默认为当前域名
+	CookieDomain string
+	// Cookie 是否仅通过 HTTPS 发送
+	CookieSecure bool
+	// Cookie 的 SameSite 属性，默认 http.SameSiteLaxMode
+	CookieSameSite http.SameSite
+
+	// Locale 来源（可选），中间件会将 Locale 注入 context，未设置的字段由语言推导
+	// 地区请求头 / Cookie，值为地区代码，如 "CN"
+	RegionHeader string
+	RegionCookie string
+	// 时区请求头 / Cookie，值为 IANA 时区名，如 "Asia/Shanghai"
+	TimezoneHeader string
+	TimezoneCookie string
+	// 货币请求头 / Cookie，值为 ISO 4217 代码，如 "EUR"
+	CurrencyHeader string
+	CurrencyCookie string
 }
 
 // DefaultMiddlewareConfig 默认中间件配置
 func DefaultMiddlewareConfig() *MiddlewareConfig {
 	return &MiddlewareConfig{
-		Sources:     []LangSource{SourceQuery, SourceCookie, SourceHeader},
-		QueryParam:  "lang",
-		CookieName:  "lang",
-		DefaultLang: "",
+		Sources:         []LangSource{SourceQuery, SourceCookie, SourceHeader},
+		QueryParam:      "lang",
+		CookieName:      "lang",
+		DefaultLang:     "",
+		ContentLanguage: false,
+		Vary:            false,
+		PersistCookie:   false,
+		CookieMaxAge:    365 * 24 * 3600,
+		CookiePath:      "/",
+		CookieSameSite:  http.SameSiteLaxMode,
 	}
 }
 
@@ -84,8 +153,16 @@ func Middleware(cfg *MiddlewareConfig) func(http.Handler) http.Handler {
 
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			lang := detectLanguage(r, cfg)
-			ctx := ContextWithLang(r.Context(), lang)
+			lang, source := detect(r, cfg)
+			if cfg.PersistCookie && source == SourceQuery {
+				persistCookie(w, r, cfg, lang)
+			}
+			setResponseHeaders(w, cfg, lang)
+			if hasSource(cfg, SourcePath) {
+				r = stripPathLang(r, cfg.bundle())
+			}
+			ctx := ContextWithBundle(r.Context(), cfg.bundle())
+			ctx = ContextWithLocale(ctx, detectLocale(r, cfg, lang))
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
@@ -93,6 +170,17 @@ func Middleware(cfg *MiddlewareConfig) func(http.Handler) http.Handler {
 
 // detectLanguage 检测请求的语言
 func detectLanguage(r *http.Request, cfg *MiddlewareConfig) string {
+	lang, _ := detect(r, cfg)
+	return lang
+}
+
+// sourceDefault 表示语言来自默认值而非任何来源
+const sourceDefault LangSource = -1
+
+// detect 检测请求的语言，并返回语言的来源
+// 各来源的值会与已加载的语言协商，未加载的语言视为无效并继续尝试下一个来源
+func detect(r *http.Request, cfg *MiddlewareConfig) (string, LangSource) {
+	b := cfg.bundle()
 	for _, source := range cfg.Sources {
 		var lang string
 		switch source {
@@ -103,39 +191,224 @@ func detectLanguage(r *http.Request, cfg *MiddlewareConfig) string {
 				lang = cookie.Value
 			}
 		case SourceHeader:
-			lang = parseAcceptLanguage(r.Header.Get("Accept-Language"))
+			lang = r.Header.Get("Accept-Language")
+		case SourcePath:
+			lang, _ = b.PathLang(r.URL.Path)
+		case SourceHost:
+			lang = hostLang(r, cfg, b)
+		default:
+			if fn, ok := customSource(source); ok {
+				lang = fn(r)
+			}
 		}
 
-		if lang != "" {
-			return normalizeLanguageTag(lang)
+		if lang = negotiate(b, lang); lang != "" {
+			return lang, source
 		}
 	}
 
 	if cfg.DefaultLang != "" {
-		return normalizeLanguageTag(cfg.DefaultLang)
+		return normalizeLanguageTag(cfg.DefaultLang), sourceDefault
 	}
-	return Default().GetLang()
+	return b.GetLang(), sourceDefault
 }
 
-// parseAcceptLanguage 解析 Accept-Language 头
-func parseAcceptLanguage(header string) string {
-	if header == "" {
-		return ""
+// bundle 返回中间件使用的 Bundle
+func (cfg *MiddlewareConfig) bundle() *Bundle {
+	if cfg.Bundle != nil {
+		return cfg.Bundle
+	}
+	return Default()
+}
+
+// hostLang 从主机名检测语言: 先查 HostLangs 映射，再按配置提取子域名
+func hostLang(r *http.Request, cfg *MiddlewareConfig, b *Bundle) string {
+	host := strings.ToLower(r.Host)
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(host, ".")
+
+	for h, lang := range cfg.HostLangs {
+		if strings.EqualFold(h, host) {
+			return lang
+		}
+	}
+
+	if cfg.HostSubdomain {
+		if label, rest, ok := strings.Cut(host, "."); ok && strings.Contains(rest, ".") {
+			return b.loadedLang(label)
+		}
+	}
+	return ""
+}
+
+// hasSource 判断配置中是否启用了指定来源
+func hasSource(cfg *MiddlewareConfig, source LangSource) bool {
+	for _, s := range cfg.Sources {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
+
+// persistCookie 将语言写入 Cookie，值未变化时不重复写入
+func persistCookie(w http.ResponseWriter, r *http.Request, cfg *MiddlewareConfig, lang string) {
+	if cookie, err := r.Cookie(cfg.CookieName); err == nil && cookie.Value == lang {
+		return
+	}
+	setLangCookie(w, cfg, lang)
+}
+
+// setLangCookie 按配置写入语言 Cookie
+func setLangCookie(w http.ResponseWriter, cfg *MiddlewareConfig, lang string) {
+	path := cfg.CookiePath
+	if path == "" {
+		path = "/"
+	}
+	sameSite := cfg.CookieSameSite
+	if sameSite == 0 {
+		sameSite = http.SameSiteLaxMode
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     cfg.CookieName,
+		Value:    lang,
+		Path:     path,
+		Domain:   cfg.CookieDomain,
+		MaxAge:   cfg.CookieMaxAge,
+		Secure:   cfg.CookieSecure,
+		HttpOnly: true,
+		SameSite: sameSite,
+	})
+}
+
+// setResponseHeaders 按配置设置 Content-Language 和 Vary 响应头
+func setResponseHeaders(w http.ResponseWriter, cfg *MiddlewareConfig, lang string) {
+	h := w.Header()
+	if cfg.ContentLanguage && lang != "" {
+		h.Set("Content-Language", lang)
+	}
+	if cfg.Vary {
+		addSourceVary(h, cfg.Sources)
+		addLocaleVary(h, cfg)
 	}
+}
 
-	// 取第一个语言，去掉权重
-	// Accept-Language: zh-CN,zh;q=0.9,en;q=0.8
-	parts := strings.Split(header, ",")
-	if len(parts) == 0 {
+// addSourceVary 按语言来源添加 Vary 字段
+func addSourceVary(h http.Header, sources []LangSource) {
+	for _, source := range sources {
+		switch source {
+		case SourceHeader:
+			addVary(h, "Accept-Language")
+		case SourceCookie:
+			addVary(h, "Cookie")
+		default:
+			if name, ok := sourceHeader(source); ok {
+				addVary(h, name)
+			}
+		}
+	}
+}
+
+// addVary 向 Vary 头追加字段，已存在时忽略
+func addVary(h http.Header, field string) {
+	for _, v := range h.Values("Vary") {
+		for _, f := range strings.Split(v, ",") {
+			f = strings.TrimSpace(f)
+			if f == "*" || strings.EqualFold(f, field) {
+				return
+			}
+		}
+	}
+	h.Add("Vary", field)
+}
+
+// negotiate 将来源中的语言与 Bundle 已加载的语言协商
+// 值按 Accept-Language 解析，依次尝试每个偏好；尚未加载任何语言时，直接使用首选语言
+func negotiate(b *Bundle, value string) string {
+	if value == "" {
 		return ""
 	}
+	if len(b.cat.Load().supported) == 0 {
+		return parseAcceptLanguage(value)
+	}
+	for _, pref := range ParseAcceptLanguage(value) {
+		if pref == "*" {
+			continue
+		}
+		if lang := b.Match(pref); lang != "" {
+			return lang
+		}
+	}
+	return ""
+}
 
-	lang := strings.TrimSpace(parts[0])
-	if idx := strings.Index(lang, ";"); idx > 0 {
-		lang = lang[:idx]
+// ParseAcceptLanguage 按 RFC 7231 解析 Accept-Language 头
+// 返回按 q 权重从高到低排列的语言列表（权重相同保持原顺序），
+// 忽略 q=0 的项、无效的 q 值和无效的语言标签，通配符保留为 "*"
+//
+//	gi18n.ParseAcceptLanguage("*;q=0.1, fr;q=0.9, de") // [de fr *]
+func ParseAcceptLanguage(header string) []string {
+	type pref struct {
+		lang string
+		q    float64
 	}
 
-	return lang
+	var prefs []pref
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		lang := strings.TrimSpace(fields[0])
+		if lang == "" {
+			continue
+		}
+
+		q := 1.0
+		valid := true
+		for _, param := range fields[1:] {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || !strings.EqualFold(strings.TrimSpace(name), "q") {
+				continue
+			}
+			v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || v < 0 || v > 1 {
+				valid = false
+				break
+			}
+			q = v
+		}
+		if !valid || q == 0 {
+			continue
+		}
+
+		if lang != "*" {
+			lang = normalizeLanguageTag(lang)
+			if _, err := language.Parse(lang); err != nil {
+				continue
+			}
+		}
+		prefs = append(prefs, pref{lang: lang, q: q})
+	}
+
+	sort.SliceStable(prefs, func(i, j int) bool {
+		return prefs[i].q > prefs[j].q
+	})
+
+	result := make([]string, len(prefs))
+	for i, p := range prefs {
+		result[i] = p.lang
+	}
+	return result
+}
+
+// parseAcceptLanguage 返回 Accept-Language 中优先级最高的具体语言
+func parseAcceptLanguage(header string) string {
+	for _, lang := range ParseAcceptLanguage(header) {
+		if lang != "*" {
+			return lang
+		}
+	}
+	return ""
 }
 
 // ========== 已废弃方法（向后兼容） ==========
@@ -0,0 +1,181 @@
+package gi18n
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// CurrencyDisplay 货币的显示方式
+type CurrencyDisplay int
+
+const (
+	CurrencySymbol CurrencyDisplay = iota // 本地化符号 €、US$（默认）
+	CurrencyCode                          // ISO 4217 代码 EUR
+	CurrencyNarrow                        // 窄符号 $
+)
+
+// currencyPattern 货币符号的位置
+type currencyPattern struct {
+	suffix bool // 符号在数字之后
+	space  bool // 符号与数字之间有空格
+}
+
+// currencyPatterns 各语言的货币格式（参考 CLDR），未列出的语言使用符号前置、无空格
+var currencyPatterns = map[string]currencyPattern{
+	"de":    {suffix: true, space: true},
+	"fr":    {suffix: true, space: true},
+	"es":    {suffix: true, space: true},
+	"it":    {suffix: true, space: true},
+	"ru":    {suffix: true, space: true},
+	"pl":    {suffix: true, space: true},
+	"cs":    {suffix: true, space: true},
+	"sv":    {suffix: true, space: true},
+	"fi":    {suffix: true, space: true},
+	"da":    {suffix: true, space: true},
+	"nb":    {suffix: true, space: true},
+	"vi":    {suffix: true, space: true},
+	"pt":    {space: true},
+	"nl":    {space: true},
+	"de-CH": {space: true},
+	"pt-PT": {suffix: true, space: true},
+	"es-MX": {},
+	"es-US": {},
+}
+
+// lookupCurrencyPattern 查找语言的货币格式
+func lookupCurrencyPattern(tag language.Tag) currencyPattern {
+	p, _ := lookupLocaleData(currencyPatterns, tag)
+	return p
+}
+
+// formatCurrency 按语言格式化金额，小数位数遵循货币的标准精度（JPY 为 0）
+func formatCurrency(tag language.Tag, amount interface{}, unit currency.Unit, display CurrencyDisplay) string {
+	scale, _ := currency.Standard.Rounding(unit)
+	num := formatNumber(tag, amount, Fraction(scale))
+
+	var symbol string
+	p := message.NewPrinter(tag)
+	switch display {
+	case CurrencyCode:
+		symbol = unit.String()
+	case CurrencyNarrow:
+		symbol = p.Sprint(currency.NarrowSymbol(unit))
+	default:
+		symbol = p.Sprint(currency.Symbol(unit))
+	}
+
+	sign := ""
+	if strings.HasPrefix(num, "-") {
+		sign, num = "-", num[1:]
+	}
+
+	pattern := lookupCurrencyPattern(tag)
+	sep := ""
+	// 符号以字母结尾/开头（如 ISO 代码、CHF）时需要空格与数字分隔
+	if pattern.space || isLetterSymbol(symbol, pattern.suffix) {
+		sep = "\u00a0"
+	}
+	if pattern.suffix {
+		return sign + num + sep + symbol
+	}
+	return sign + symbol + sep + num
+}
+
+// isLetterSymbol 判断符号靠近数字的一侧是否为字母
+func isLetterSymbol(symbol string, suffix bool) bool {
+	runes := []rune(symbol)
+	if len(runes) == 0 {
+		return false
+	}
+	if suffix {
+		return unicode.IsLetter(runes[0])
+	}
+	return unicode.IsLetter(runes[len(runes)-1])
+}
+
+// parseCurrency 解析 ISO 4217 货币代码
+func parseCurrency(code string) (currency.Unit, error) {
+	unit, err := currency.ParseISO(strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		return currency.Unit{}, fmt.Errorf("gi18n: invalid currency code %q: %w", code, err)
+	}
+	return unit, nil
+}
+
+// currencyDisplay 取可选参数中的显示方式
+func currencyDisplay(display []CurrencyDisplay) CurrencyDisplay {
+	if len(display) > 0 {
+		return display[0]
+	}
+	return CurrencySymbol
+}
+
+// FormatCurrency 按语言格式化金额，code 为 ISO 4217 货币代码
+// 符号位置和小数位数由语言和货币决定，无效的货币代码原样附在数字后
+//
+//	bundle.FormatCurrency("en", 1234.5, "EUR")                     // "€1,234.50"
+//	bundle.FormatCurrency("de", 1234.5, "EUR")                     // "1.234,50 €"
+//	bundle.FormatCurrency("ja", 1234, "JPY")                       // "￥1,234"
+//	bundle.FormatCurrency("en", 1234.5, "USD", gi18n.CurrencyCode) // "USD 1,234.50"
+func (b *Bundle) FormatCurrency(lang string, amount interface{}, code string, display ...CurrencyDisplay) string {
+	tag := parseLanguageTag(lang)
+	unit, err := parseCurrency(code)
+	if err != nil {
+		return formatNumber(tag, amount) + " " + code
+	}
+	return formatCurrency(tag, amount, unit, currencyDisplay(display))
+}
+
+// FormatCurrency 按绑定的 Locale 格式化金额，code 为空时使用 Locale 的货币
+func (t *Translator) FormatCurrency(amount interface{}, code string, display ...CurrencyDisplay) string {
+	unit := t.locale.Currency
+	if code != "" {
+		u, err := parseCurrency(code)
+		if err != nil {
+			return formatNumber(t.locale.Tag(), amount) + " " + code
+		}
+		unit = u
+	}
+	return formatCurrency(t.locale.Tag(), amount, unit, currencyDisplay(display))
+}
+
+// currencyFunc 消息模板中的 currency 函数
+//
+//	{{currency .Amount}}               // 使用 Locale 的货币
+//	{{currency .Amount "EUR"}}         // 指定货币
+//	{{currency .Amount "EUR" "code"}}  // 显示方式: symbol / code / narrow
+func currencyFunc(l Locale) func(amount interface{}, args ...string) (string, error) {
+	return func(amount interface{}, args ...string) (string, error) {
+		unit := l.Currency
+		if len(args) > 0 && args[0] != "" {
+			u, err := parseCurrency(args[0])
+			if err != nil {
+				return "", err
+			}
+			unit = u
+		}
+
+		display := CurrencySymbol
+		if len(args) > 1 {
+			switch args[1] {
+			case "code", "iso":
+				display = CurrencyCode
+			case "narrow":
+				display = CurrencyNarrow
+			}
+		}
+		return formatCurrency(l.Tag(), amount, unit, display), nil
+	}
+}
+
+// ========== 全局函数 ==========
+
+// FormatCurrency 按语言格式化金额（全局）
+func FormatCurrency(lang string, amount interface{}, code string, display ...CurrencyDisplay) string {
+	return Default().FormatCurrency(lang, amount, code, display...)
+}
@@ -0,0 +1,322 @@
+package gi18n
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// DateStyle 日期时间的格式风格
+type DateStyle int
+
+const (
+	DateShort  DateStyle = iota // 1/2/06、3:04 PM
+	DateMedium                  // Jan 2, 2006、3:04:05 PM
+	DateLong                    // January 2, 2006、3:04:05 PM MST
+	DateFull                    // Monday, January 2, 2006
+)
+
+// DateOption 日期时间格式化选项
+type DateOption func(*dateConfig)
+
+// dateConfig 日期时间格式化内部配置
+type dateConfig struct {
+	location *time.Location
+	hour12   *bool
+}
+
+// InLocation 在指定时区下格式化，未设置时使用时间值自身的时区
+//
+//	bundle.FormatTime("zh-CN", t, gi18n.DateShort, gi18n.InLocation(shanghai))
+func InLocation(loc *time.Location) DateOption {
+	return func(c *dateConfig) {
+		c.location = loc
+	}
+}
+
+// Hour12 使用 12 小时制，覆盖语言的默认设置
+func Hour12() DateOption {
+	return func(c *dateConfig) {
+		v := true
+		c.hour12 = &v
+	}
+}
+
+// Hour24 使用 24 小时制，覆盖语言的默认设置
+func Hour24() DateOption {
+	return func(c *dateConfig) {
+		v := false
+		c.hour12 = &v
+	}
+}
+
+// newDateConfig 合并日期时间格式化选项
+func newDateConfig(opts []DateOption) *dateConfig {
+	c := &dateConfig{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// lookupDateSymbols 查找语言的日期格式数据，没有内置数据的语言使用英语
+func lookupDateSymbols(tag language.Tag) *dateSymbols {
+	if s, ok := lookupLocaleData(dateLocales, tag); ok {
+		return s
+	}
+	return dateLocales["en"]
+}
+
+// clampStyle 将超出范围的风格修正为 DateMedium
+func clampStyle(style DateStyle) DateStyle {
+	if style < DateShort || style > DateFull {
+		return DateMedium
+	}
+	return style
+}
+
+// timePattern 返回指定风格和小时制的时间模式
+func (s *dateSymbols) timePattern(style DateStyle, c *dateConfig) string {
+	hour12 := s.hour12
+	if c.hour12 != nil {
+		hour12 = *c.hour12
+	}
+	if hour12 {
+		return s.times12[style]
+	}
+	return s.times24[style]
+}
+
+// formatDate 按语言格式化日期
+func formatDate(tag language.Tag, t time.Time, style DateStyle, opts ...DateOption) string {
+	c := newDateConfig(opts)
+	s := lookupDateSymbols(tag)
+	return s.format(c.in(t), s.dates[clampStyle(style)])
+}
+
+// formatTime 按语言格式化时间
+func formatTime(tag language.Tag, t time.Time, style DateStyle, opts ...DateOption) string {
+	c := newDateConfig(opts)
+	s := lookupDateSymbols(tag)
+	return s.format(c.in(t), s.timePattern(clampStyle(style), c))
+}
+
+// formatDateTime 按语言格式化日期和时间
+// 时间部分最多使用 DateMedium 风格，避免完整风格下过长
+func formatDateTime(tag language.Tag, t time.Time, style DateStyle, opts ...DateOption) string {
+	c := newDateConfig(opts)
+	s := lookupDateSymbols(tag)
+	t = c.in(t)
+	style = clampStyle(style)
+
+	timeStyle, glue := style, s.dateTime[0]
+	if style >= DateLong {
+		timeStyle, glue = DateMedium, s.dateTime[1]
+	}
+	r := strings.NewReplacer(
+		"{0}", s.format(t, s.timePattern(timeStyle, c)),
+		"{1}", s.format(t, s.dates[style]),
+	)
+	return r.Replace(glue)
+}
+
+// in 将时间转换到配置的时区
+func (c *dateConfig) in(t time.Time) time.Time {
+	if c.location != nil {
+		return t.In(c.location)
+	}
+	return t
+}
+
+// format 按 CLDR 风格的模式格式化时间
+//
+// 支持的字段: y yy M MM MMM MMMM d dd E EEEE H HH h hh K m mm s ss a z，
+// 单引号内为原样输出的文本，” 表示单引号本身
+func (s *dateSymbols) format(t time.Time, pattern string) string {
+	var sb strings.Builder
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		if r == '\'' {
+			if i+1 < len(runes) && runes[i+1] == '\'' {
+				sb.WriteRune('\'')
+				i += 2
+				continue
+			}
+			i++
+			for i < len(runes) {
+				if runes[i] == '\'' {
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						sb.WriteRune('\'')
+						i += 2
+						continue
+					}
+					break
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			i++
+			continue
+		}
+
+		if !isPatternLetter(r) {
+			sb.WriteRune(r)
+			i++
+			continue
+		}
+
+		n := 1
+		for i+n < len(runes) && runes[i+n] == r {
+			n++
+		}
+		sb.WriteString(s.field(t, r, n))
+		i += n
+	}
+	return sb.String()
+}
+
+// field 格式化单个模式字段
+func (s *dateSymbols) field(t time.Time, r rune, n int) string {
+	switch r {
+	case 'y':
+		if n == 2 {
+			return pad(t.Year()%100, 2)
+		}
+		return pad(t.Year(), n)
+	case 'M':
+		switch {
+		case n >= 4:
+			return s.months[t.Month()-1]
+		case n == 3:
+			return s.shortMonths[t.Month()-1]
+		}
+		return pad(int(t.Month()), n)
+	case 'd':
+		return pad(t.Day(), n)
+	case 'E':
+		if n >= 4 {
+			return s.weekdays[t.Weekday()]
+		}
+		return s.shortWeekdays[t.Weekday()]
+	case 'H':
+		return pad(t.Hour(), n)
+	case 'h':
+		h := t.Hour() % 12
+		if h == 0 {
+			h = 12
+		}
+		return pad(h, n)
+	case 'K':
+		return pad(t.Hour()%12, n)
+	case 'm':
+		return pad(t.Minute(), n)
+	case 's':
+		return pad(t.Second(), n)
+	case 'a':
+		if t.Hour() < 12 {
+			return s.am
+		}
+		return s.pm
+	case 'z':
+		return t.Format("MST")
+	}
+	return strings.Repeat(string(r), n)
+}
+
+// isPatternLetter 判断是否为模式字段字母
+func isPatternLetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+// pad 将数字左侧补零到指定宽度
+func pad(v, width int) string {
+	s := strconv.Itoa(v)
+	if len(s) < width {
+		s = strings.Repeat("0", width-len(s)) + s
+	}
+	return s
+}
+
+// parseDateStyle 解析模板函数中的风格名称，未知名称使用 DateMedium
+func parseDateStyle(args []string) DateStyle {
+	if len(args) == 0 {
+		return DateMedium
+	}
+	switch strings.ToLower(args[0]) {
+	case "short":
+		return DateShort
+	case "long":
+		return DateLong
+	case "full":
+		return DateFull
+	}
+	return DateMedium
+}
+
+// FormatDate 按语言格式化日期，月份和星期名称使用该语言
+//
+//	bundle.FormatDate("en", t, gi18n.DateLong)    // "January 2, 2006"
+//	bundle.FormatDate("de", t, gi18n.DateFull)    // "Montag, 2. Januar 2006"
+//	bundle.FormatDate("zh-CN", t, gi18n.DateLong) // "2006年1月2日"
+func (b *Bundle) FormatDate(lang string, t time.Time, style DateStyle, opts ...DateOption) string {
+	return formatDate(parseLanguageTag(lang), t, style, opts...)
+}
+
+// FormatTime 按语言格式化时间，12/24 小时制由语言决定，可通过 Hour12/Hour24 覆盖
+//
+//	bundle.FormatTime("en", t, gi18n.DateShort) // "3:04 PM"
+//	bundle.FormatTime("de", t, gi18n.DateShort) // "15:04"
+func (b *Bundle) FormatTime(lang string, t time.Time, style DateStyle, opts ...DateOption) string {
+	return formatTime(parseLanguageTag(lang), t, style, opts...)
+}
+
+// FormatDateTime 按语言格式化日期和时间
+//
+//	bundle.FormatDateTime("en", t, gi18n.DateMedium) // "Jan 2, 2006, 3:04:05 PM"
+func (b *Bundle) FormatDateTime(lang string, t time.Time, style DateStyle, opts ...DateOption) string {
+	return formatDateTime(parseLanguageTag(lang), t, style, opts...)
+}
+
+// dateOptions 在选项前加入 Locale 的时区，调用方的选项优先
+func (t *Translator) dateOptions(opts []DateOption) []DateOption {
+	if t.locale.Location == nil {
+		return opts
+	}
+	return append([]DateOption{InLocation(t.locale.Location)}, opts...)
+}
+
+// FormatDate 按绑定的 Locale 格式化日期，使用 Locale 的时区
+func (t *Translator) FormatDate(v time.Time, style DateStyle, opts ...DateOption) string {
+	return formatDate(t.locale.Tag(), v, style, t.dateOptions(opts)...)
+}
+
+// FormatTime 按绑定的 Locale 格式化时间，使用 Locale 的时区
+func (t *Translator) FormatTime(v time.Time, style DateStyle, opts ...DateOption) string {
+	return formatTime(t.locale.Tag(), v, style, t.dateOptions(opts)...)
+}
+
+// FormatDateTime 按绑定的 Locale 格式化日期和时间，使用 Locale 的时区
+func (t *Translator) FormatDateTime(v time.Time, style DateStyle, opts ...DateOption) string {
+	return formatDateTime(t.locale.Tag(), v, style, t.dateOptions(opts)...)
+}
+
+// ========== 全局函数 ==========
+
+// FormatDate 按语言格式化日期（全局）
+func FormatDate(lang string, t time.Time, style DateStyle, opts ...DateOption) string {
+	return Default().FormatDate(lang, t, style, opts...)
+}
+
+// FormatTime 按语言格式化时间（全局）
+func FormatTime(lang string, t time.Time, style DateStyle, opts ...DateOption) string {
+	return Default().FormatTime(lang, t, style, opts...)
+}
+
+// FormatDateTime 按语言格式化日期和时间（全局）
+func FormatDateTime(lang string, t time.Time, style DateStyle, opts ...DateOption) string {
+	return Default().FormatDateTime(lang, t, style, opts...)
+}
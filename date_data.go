@@ -0,0 +1,198 @@
+package gi18n
+
+// dateSymbols 单个语言的日期格式数据（参考 CLDR）
+// 模式数组按 DateShort、DateMedium、DateLong、DateFull 排列
+type dateSymbols struct {
+	dates   [4]string
+	times24 [4]string
+	times12 [4]string
+	hour12  bool // 默认使用 12 小时制
+
+	// dateTime 日期与时间的组合方式，{1} 为日期、{0} 为时间，按原样替换
+	// 依次用于 Short/Medium 和 Long/Full
+	dateTime [2]string
+
+	months        [12]string
+	shortMonths   [12]string
+	weekdays      [7]string // 从星期日开始
+	shortWeekdays [7]string
+	am, pm        string
+}
+
+// 常用的时间模式
+var (
+	times24 = [4]string{"HH:mm", "HH:mm:ss", "HH:mm:ss z", "HH:mm:ss z"}
+	times12 = [4]string{"h:mm a", "h:mm:ss a", "h:mm:ss a z", "h:mm:ss a z"}
+)
+
+// dateLocales 内置的语言日期格式数据
+var dateLocales = map[string]*dateSymbols{
+	"en": {
+		dates:    [4]string{"M/d/yy", "MMM d, y", "MMMM d, y", "EEEE, MMMM d, y"},
+		times24:  times24,
+		times12:  times12,
+		hour12:   true,
+		dateTime: [2]string{"{1}, {0}", "{1} at {0}"},
+		months: [12]string{"January", "February", "March", "April", "May", "June",
+			"July", "August", "September", "October", "November", "December"},
+		shortMonths: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun",
+			"Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		weekdays:      [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		shortWeekdays: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		am:            "AM",
+		pm:            "PM",
+	},
+	"zh": {
+		dates:    [4]string{"y/M/d", "y年M月d日", "y年M月d日", "y年M月d日EEEE"},
+		times24:  [4]string{"HH:mm", "HH:mm:ss", "z HH:mm:ss", "z HH:mm:ss"},
+		times12:  [4]string{"ah:mm", "ah:mm:ss", "z ah:mm:ss", "z ah:mm:ss"},
+		dateTime: [2]string{"{1} {0}", "{1} {0}"},
+		months: [12]string{"一月", "二月", "三月", "四月", "五月", "六月",
+			"七月", "八月", "九月", "十月", "十一月", "十二月"},
+		shortMonths: [12]string{"1月", "2月", "3月", "4月", "5月", "6月",
+			"7月", "8月", "9月", "10月", "11月", "12月"},
+		weekdays:      [7]string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+		shortWeekdays: [7]string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"},
+		am:            "上午",
+		pm:            "下午",
+	},
+	"zh-Hant": {
+		dates:    [4]string{"y/M/d", "y年M月d日", "y年M月d日", "y年M月d日 EEEE"},
+		times24:  [4]string{"HH:mm", "HH:mm:ss", "HH:mm:ss [z]", "HH:mm:ss [z]"},
+		times12:  [4]string{"ah:mm", "ah:mm:ss", "ah:mm:ss [z]", "ah:mm:ss [z]"},
+		hour12:   true,
+		dateTime: [2]string{"{1} {0}", "{1} {0}"},
+		months: [12]string{"1月", "2月", "3月", "4月", "5月", "6月",
+			"7月", "8月", "9月", "10月", "11月", "12月"},
+		shortMonths: [12]string{"1月", "2月", "3月", "4月", "5月", "6月",
+			"7月", "8月", "9月", "10月", "11月", "12月"},
+		weekdays:      [7]string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+		shortWeekdays: [7]string{"週日", "週一", "週二", "週三", "週四", "週五", "週六"},
+		am:            "上午",
+		pm:            "下午",
+	},
+	"ja": {
+		dates:    [4]string{"y/MM/dd", "y/MM/dd", "y年M月d日", "y年M月d日EEEE"},
+		times24:  [4]string{"H:mm", "H:mm:ss", "H:mm:ss z", "H時mm分ss秒 z"},
+		times12:  [4]string{"aK:mm", "aK:mm:ss", "aK:mm:ss z", "aK:mm:ss z"},
+		dateTime: [2]string{"{1} {0}", "{1} {0}"},
+		months: [12]string{"1月", "2月", "3月", "4月", "5月", "6月",
+			"7月", "8月", "9月", "10月", "11月", "12月"},
+		shortMonths: [12]string{"1月", "2月", "3月", "4月", "5月", "6月",
+			"7月", "8月", "9月", "10月", "11月", "12月"},
+		weekdays:      [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+		shortWeekdays: [7]string{"日", "月", "火", "水", "木", "金", "土"},
+		am:            "午前",
+		pm:            "午後",
+	},
+	"ko": {
+		dates:    [4]string{"yy. M. d.", "y. M. d.", "y년 M월 d일", "y년 M월 d일 EEEE"},
+		times24:  [4]string{"H:mm", "H:mm:ss", "H시 m분 s초 z", "H시 m분 s초 z"},
+		times12:  [4]string{"a h:mm", "a h:mm:ss", "a h시 m분 s초 z", "a h시 m분 s초 z"},
+		hour12:   true,
+		dateTime: [2]string{"{1} {0}", "{1} {0}"},
+		months: [12]string{"1월", "2월", "3월", "4월", "5월", "6월",
+			"7월", "8월", "9월", "10월", "11월", "12월"},
+		shortMonths: [12]string{"1월", "2월", "3월", "4월", "5월", "6월",
+			"7월", "8월", "9월", "10월", "11월", "12월"},
+		weekdays:      [7]string{"일요일", "월요일", "화요일", "수요일", "목요일", "금요일", "토요일"},
+		shortWeekdays: [7]string{"일", "월", "화", "수", "목", "금", "토"},
+		am:            "오전",
+		pm:            "오후",
+	},
+	"de": {
+		dates:    [4]string{"dd.MM.yy", "dd.MM.y", "d. MMMM y", "EEEE, d. MMMM y"},
+		times24:  times24,
+		times12:  times12,
+		dateTime: [2]string{"{1}, {0}", "{1} um {0}"},
+		months: [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni",
+			"Juli", "August", "September", "Oktober", "November", "Dezember"},
+		shortMonths: [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni",
+			"Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		weekdays:      [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		shortWeekdays: [7]string{"So.", "Mo.", "Di.", "Mi.", "Do.", "Fr.", "Sa."},
+		am:            "AM",
+		pm:            "PM",
+	},
+	"fr": {
+		dates:    [4]string{"dd/MM/y", "d MMM y", "d MMMM y", "EEEE d MMMM y"},
+		times24:  times24,
+		times12:  times12,
+		dateTime: [2]string{"{1} {0}", "{1} à {0}"},
+		months: [12]string{"janvier", "février", "mars", "avril", "mai", "juin",
+			"juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		shortMonths: [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin",
+			"juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		weekdays:      [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		shortWeekdays: [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		am:            "AM",
+		pm:            "PM",
+	},
+	"es": {
+		dates:    [4]string{"d/M/yy", "d MMM y", "d 'de' MMMM 'de' y", "EEEE, d 'de' MMMM 'de' y"},
+		times24:  [4]string{"H:mm", "H:mm:ss", "H:mm:ss z", "H:mm:ss z"},
+		times12:  times12,
+		dateTime: [2]string{"{1}, {0}", "{1}, {0}"},
+		months: [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio",
+			"julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		shortMonths: [12]string{"ene", "feb", "mar", "abr", "may", "jun",
+			"jul", "ago", "sept", "oct", "nov", "dic"},
+		weekdays:      [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		shortWeekdays: [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		am:            "a. m.",
+		pm:            "p. m.",
+	},
+	"it": {
+		dates:    [4]string{"dd/MM/yy", "d MMM y", "d MMMM y", "EEEE d MMMM y"},
+		times24:  times24,
+		times12:  times12,
+		dateTime: [2]string{"{1}, {0}", "{1} alle ore {0}"},
+		months: [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno",
+			"luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		shortMonths: [12]string{"gen", "feb", "mar", "apr", "mag", "giu",
+			"lug", "ago", "set", "ott", "nov", "dic"},
+		weekdays:      [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+		shortWeekdays: [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+		am:            "AM",
+		pm:            "PM",
+	},
+	"pt": {
+		dates:    [4]string{"dd/MM/y", "d 'de' MMM 'de' y", "d 'de' MMMM 'de' y", "EEEE, d 'de' MMMM 'de' y"},
+		times24:  times24,
+		times12:  times12,
+		dateTime: [2]string{"{1} {0}", "{1} {0}"},
+		months: [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho",
+			"julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		shortMonths: [12]string{"jan.", "fev.", "mar.", "abr.", "mai.", "jun.",
+			"jul.", "ago.", "set.", "out.", "nov.", "dez."},
+		weekdays: [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira",
+			"quinta-feira", "sexta-feira", "sábado"},
+		shortWeekdays: [7]string{"dom.", "seg.", "ter.", "qua.", "qui.", "sex.", "sáb."},
+		am:            "AM",
+		pm:            "PM",
+	},
+	"ru": {
+		dates:    [4]string{"dd.MM.y", "d MMM y 'г'.", "d MMMM y 'г'.", "EEEE, d MMMM y 'г'."},
+		times24:  times24,
+		times12:  times12,
+		dateTime: [2]string{"{1}, {0}", "{1}, {0}"},
+		// 日期中的月份使用属格
+		months: [12]string{"января", "февраля", "марта", "апреля", "мая", "июня",
+			"июля", "августа", "сентября", "октября", "ноября", "декабря"},
+		shortMonths: [12]string{"янв.", "февр.", "мар.", "апр.", "мая", "июн.",
+			"июл.", "авг.", "сент.", "окт.", "нояб.", "дек."},
+		weekdays:      [7]string{"воскресенье", "понедельник", "вторник", "среда", "четверг", "пятница", "суббота"},
+		shortWeekdays: [7]string{"вс", "пн", "вт", "ср", "чт", "пт", "сб"},
+		am:            "AM",
+		pm:            "PM",
+	},
+}
+
+func init() {
+	// 英式英语：日在月前，24 小时制
+	gb := *dateLocales["en"]
+	gb.dates = [4]string{"dd/MM/y", "d MMM y", "d MMMM y", "EEEE d MMMM y"}
+	gb.hour12 = false
+	gb.dateTime = [2]string{"{1}, {0}", "{1} at {0}"}
+	dateLocales["en-GB"] = &gb
+}
@@ -1,6 +1,9 @@
 package gi18n
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // 预定义错误
 var (
@@ -10,8 +13,73 @@ var (
 	ErrInvalidFormat = errors.New("gi18n: invalid file format")
 	// ErrEmptyID 空的消息 ID
 	ErrEmptyID = errors.New("gi18n: empty message ID")
+	// ErrTemplate 消息模板解析或执行失败
+	ErrTemplate = errors.New("gi18n: template error")
+	// ErrPlural 复数处理失败
+	ErrPlural = errors.New("gi18n: plural error")
+	// ErrNoSnapshot 没有可回滚的翻译快照
+	ErrNoSnapshot = errors.New("gi18n: no snapshot to roll back to")
+	// ErrInvalidFunc 无效的模板函数
+	ErrInvalidFunc = errors.New("gi18n: invalid template function")
 )
 
+// NotFoundError 翻译消息不存在
+// errors.Is(err, ErrMessageNotFound) 返回 true
+type NotFoundError struct {
+	Lang string // 请求的语言
+	ID   string // 消息 ID
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("gi18n: message %q not found for language %q", e.ID, e.Lang)
+}
+
+// Is 支持 errors.Is(err, ErrMessageNotFound)
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrMessageNotFound
+}
+
+// TemplateError 消息模板解析或执行失败
+// errors.Is(err, ErrTemplate) 返回 true
+type TemplateError struct {
+	Lang string
+	ID   string
+	Err  error // 底层模板错误
+}
+
+func (e *TemplateError) Error() string {
+	return fmt.Sprintf("gi18n: template error in message %q for language %q: %v", e.ID, e.Lang, e.Err)
+}
+
+// Unwrap 返回底层模板错误
+func (e *TemplateError) Unwrap() error { return e.Err }
+
+// Is 支持 errors.Is(err, ErrTemplate)
+func (e *TemplateError) Is(target error) bool {
+	return target == ErrTemplate
+}
+
+// PluralError 复数处理失败，如计数无效或消息缺少对应的复数形式
+// errors.Is(err, ErrPlural) 返回 true
+type PluralError struct {
+	Lang  string
+	ID    string
+	Count int
+	Err   error // go-i18n 返回的原始错误
+}
+
+func (e *PluralError) Error() string {
+	return fmt.Sprintf("gi18n: plural error in message %q for language %q (count %d): %v", e.ID, e.Lang, e.Count, e.Err)
+}
+
+// Unwrap 返回 go-i18n 返回的原始错误
+func (e *PluralError) Unwrap() error { return e.Err }
+
+// Is 支持 errors.Is(err, ErrPlural)
+func (e *PluralError) Is(target error) bool {
+	return target == ErrPlural
+}
+
 // MissPolicy 翻译缺失时的处理策略
 type MissPolicy int
 
@@ -0,0 +1,141 @@
+package gi18n
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+// SetFallbackChain 设置指定语言的回退链
+//
+//	bundle.SetFallbackChain("zh-HK", "zh-TW", "zh-CN")
+//	bundle.SetFallbackChain("pt-BR", "pt-PT")
+//
+// 翻译时依次尝试: 请求语言 > 回退链 > 请求语言的父语言（en-GB > en）> 回退语言，
+// 不传 chain 时删除该语言的回退链
+func (b *Bundle) SetFallbackChain(lang string, chain ...string) {
+	b.mu.Lock()
+	if b.fallbackChains == nil {
+		b.fallbackChains = make(map[string][]string)
+	}
+	key := canonicalLang(lang)
+	if len(chain) == 0 {
+		delete(b.fallbackChains, key)
+	} else {
+		b.fallbackChains[key] = normalizeChain(chain)
+	}
+	b.mu.Unlock()
+	b.clearLocalizerCache()
+}
+
+// FallbackChain 返回翻译指定语言时依次尝试的完整语言列表
+//
+//	bundle.FallbackChain("zh-HK") // [zh-HK zh-TW zh-CN zh en]
+func (b *Bundle) FallbackChain(lang string) []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var result []string
+	seen := make(map[string]struct{})
+	add := func(l string) {
+		key := canonicalLang(l)
+		if _, ok := seen[key]; ok || l == "" {
+			return
+		}
+		seen[key] = struct{}{}
+		result = append(result, normalizeLanguageTag(l))
+	}
+
+	normalized := normalizeLanguageTag(lang)
+	add(normalized)
+	for _, l := range b.fallbackChains[canonicalLang(normalized)] {
+		add(l)
+	}
+	for _, l := range parentLangs(normalized) {
+		add(l)
+	}
+	add(b.fallbackLang)
+	for _, l := range parentLangs(b.fallbackLang) {
+		add(l)
+	}
+	return result
+}
+
+// localizeChain 沿回退链逐个尝试已加载的语言，消息缺失时继续下一个
+// 回退链上都没有该消息时，交给 go-i18n 的语言匹配做最后一次尝试
+func (b *Bundle) localizeChain(lang string, lc *i18n.LocalizeConfig) (string, language.Tag, error) {
+	c := b.cat.Load()
+	for _, loc := range b.chainLocalizers(c, lang) {
+		msg, tag, err := loc.LocalizeWithTag(lc)
+		var notFound *i18n.MessageNotFoundErr
+		if errors.As(err, &notFound) {
+			continue
+		}
+		return msg, tag, err
+	}
+
+	return b.getLocalizer(c, lang).LocalizeWithTag(lc)
+}
+
+// chainLocalizers 返回回退链上已加载语言的 Localizer（按语言缓存在翻译目录中）
+func (b *Bundle) chainLocalizers(c *catalog, lang string) []*i18n.Localizer {
+	if v, ok := c.chains.Load(lang); ok {
+		return v.([]*i18n.Localizer)
+	}
+
+	loaded := make(map[string]struct{}, len(c.supported))
+	for _, l := range c.supported {
+		loaded[canonicalLang(l)] = struct{}{}
+	}
+
+	var locs []*i18n.Localizer
+	for _, candidate := range b.FallbackChain(lang) {
+		if _, ok := loaded[canonicalLang(candidate)]; ok {
+			locs = append(locs, b.getLocalizer(c, candidate))
+		}
+	}
+	c.chains.Store(lang, locs)
+	return locs
+}
+
+// parentLangs 逐级去掉末尾子标签得到父语言: zh-Hant-TW > zh-Hant > zh
+func parentLangs(lang string) []string {
+	var parents []string
+	normalized := normalizeLanguageTag(lang)
+	for {
+		idx := strings.LastIndex(normalized, "-")
+		if idx <= 0 {
+			return parents
+		}
+		normalized = normalized[:idx]
+		parents = append(parents, normalized)
+	}
+}
+
+// canonicalLang 返回语言标签的规范形式，用于比较: zh_cn > zh-CN
+func canonicalLang(lang string) string {
+	tag, err := language.Parse(normalizeLanguageTag(lang))
+	if err != nil {
+		return normalizeLanguageTag(lang)
+	}
+	return tag.String()
+}
+
+// normalizeChain 标准化回退链中的语言标签
+func normalizeChain(chain []string) []string {
+	result := make([]string, len(chain))
+	for i, l := range chain {
+		result[i] = normalizeLanguageTag(l)
+	}
+	return result
+}
+
+// ========== 全局函数 ==========
+
+// SetFallbackChain 设置指定语言的回退链（全局）
+func SetFallbackChain(lang string, chain ...string) { Default().SetFallbackChain(lang, chain...) }
+
+// FallbackChain 返回指定语言的完整回退链（全局）
+func FallbackChain(lang string) []string { return Default().FallbackChain(lang) }
@@ -0,0 +1,262 @@
+package gi18n
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"text/template"
+	"time"
+	"unicode"
+
+	i18ntemplate "github.com/nicksnyder/go-i18n/v2/i18n/template"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// maxCachedLocales 缓存模板解析器的 Locale 数量上限，超出后不再缓存新的 Locale
+const maxCachedLocales = 256
+
+// parserCache 按 Locale 缓存的模板解析器，注册模板函数时整体替换
+type parserCache struct {
+	parsers sync.Map // map[string]*templateParser
+	size    atomic.Int32
+}
+
+// templateParser 绑定某个 Locale 的消息模板解析器
+//
+// 内置函数依赖 Locale，带函数的 TextParser 不可缓存，go-i18n 每次翻译都会重新解析模板；
+// 这里每个 Locale 只构建一次函数表，并按模板文本缓存解析结果
+type templateParser struct {
+	funcs     template.FuncMap
+	templates sync.Map // map[templateKey]i18ntemplate.ParsedTemplate
+}
+
+// templateKey 模板缓存键
+type templateKey struct {
+	src, leftDelim, rightDelim string
+}
+
+// Cacheable 解析结果绑定 Locale，不能缓存在 go-i18n 的消息中
+func (p *templateParser) Cacheable() bool { return false }
+
+// Parse 解析消息模板，相同的模板只解析一次
+func (p *templateParser) Parse(src, leftDelim, rightDelim string) (i18ntemplate.ParsedTemplate, error) {
+	key := templateKey{src, leftDelim, rightDelim}
+	if pt, ok := p.templates.Load(key); ok {
+		return pt.(i18ntemplate.ParsedTemplate), nil
+	}
+	pt, err := (&i18ntemplate.TextParser{Funcs: p.funcs}).Parse(src, leftDelim, rightDelim)
+	if err != nil {
+		return nil, err
+	}
+	p.templates.Store(key, pt)
+	return pt, nil
+}
+
+// templateParser 返回 Locale 对应的模板解析器（带缓存）
+func (b *Bundle) templateParser(l Locale) *templateParser {
+	cache := b.parsers.Load()
+	key := l.Lang + "|" + l.Region + "|" + l.Location.String() + "|" + l.Currency.String()
+	if p, ok := cache.parsers.Load(key); ok {
+		return p.(*templateParser)
+	}
+
+	p := &templateParser{funcs: b.templateFuncs(l)}
+	if cache.size.Load() >= maxCachedLocales {
+		return p
+	}
+	actual, loaded := cache.parsers.LoadOrStore(key, p)
+	if !loaded {
+		cache.size.Add(1)
+	}
+	return actual.(*templateParser)
+}
+
+// templateFuncs 返回消息模板中可用的内置函数，按 Locale 格式化
+//
+//	"Total: {{number .Amount}}"                      // 1,234,567.5
+//	"Rate: {{number .Rate 2}}"                       // 固定 2 位小数
+//	"Done: {{percent .Ratio}}"                       // 26%
+//	"Pay: {{currency .Price "EUR"}}"                 // €9.90
+//	"Sent: {{date .At "long"}} {{time .At "short"}}" // 使用 Locale 的时区
+//	"Updated {{relative .UpdatedAt}}"                // 5 minutes ago
+//	"With {{list .Names}}"                           // Alice, Bob, and Carol
+//	"{{compact .Views}} views, {{bytes .Size}}"      // 1.2K views, 3.4 GB
+//	"Took {{duration .Elapsed "long"}}"              // Took 1 hour, 30 minutes
+//	"Hi {{.Name | upper}}"                           // 按语言转换大小写
+//	"{{.Count}} {{plural .Count "one" "item" "other" "items"}}"
+//
+// Config.Funcs 和 RegisterFunc 注册的函数追加在内置函数之后，同名时覆盖内置函数
+func (b *Bundle) templateFuncs(l Locale) template.FuncMap {
+	tag := l.Tag()
+	funcs := template.FuncMap{
+		"number": func(v interface{}, digits ...int) string {
+			return formatNumber(tag, v, fractionOptions(digits)...)
+		},
+		"percent": func(v interface{}, digits ...int) string {
+			return formatPercent(tag, v, fractionOptions(digits)...)
+		},
+		"currency": currencyFunc(l),
+		"date": func(t time.Time, style ...string) string {
+			return formatDate(tag, t, parseDateStyle(style), InLocation(l.Location))
+		},
+		"time": func(t time.Time, style ...string) string {
+			return formatTime(tag, t, parseDateStyle(style), InLocation(l.Location))
+		},
+		"datetime": func(t time.Time, style ...string) string {
+			return formatDateTime(tag, t, parseDateStyle(style), InLocation(l.Location))
+		},
+		"relative": b.relativeFunc(l.Lang),
+		"list":     b.listFunc(l.Lang),
+		"compact": func(v interface{}, style ...string) (string, error) {
+			f, err := toFloat64(v)
+			if err != nil {
+				return "", err
+			}
+			return formatCompact(tag, f, parseUnitStyle(style)), nil
+		},
+		"bytes": func(v interface{}) (string, error) {
+			f, err := toFloat64(v)
+			if err != nil {
+				return "", err
+			}
+			return formatBytes(tag, int64(f)), nil
+		},
+		"duration": func(d time.Duration, style ...string) string {
+			return b.FormatDuration(l.Lang, d, parseUnitStyle(style))
+		},
+		"distance": func(v interface{}, style ...string) (string, error) {
+			f, err := toFloat64(v)
+			if err != nil {
+				return "", err
+			}
+			return formatDistance(tag, f, parseUnitStyle(style)), nil
+		},
+		"upper": func(v interface{}) string {
+			return cases.Upper(tag).String(fmt.Sprint(v))
+		},
+		"lower": func(v interface{}) string {
+			return cases.Lower(tag).String(fmt.Sprint(v))
+		},
+		"title": func(v interface{}) string {
+			return cases.Title(tag).String(fmt.Sprint(v))
+		},
+		"plural": func(count interface{}, pairs ...string) (string, error) {
+			form, err := countPluralForm(tag, count)
+			if err != nil {
+				return "", err
+			}
+			return pickPluralPair(form, pairs)
+		},
+		"pluralForm": func(count interface{}) (string, error) {
+			return countPluralForm(tag, count)
+		},
+	}
+
+	b.mu.RLock()
+	for name, fn := range b.funcs {
+		funcs[name] = fn
+	}
+	b.mu.RUnlock()
+	return funcs
+}
+
+// countPluralForm 按语言的复数规则计算计数对应的复数形式，支持整数和小数
+func countPluralForm(tag language.Tag, count interface{}) (string, error) {
+	f, err := toFloat64(count)
+	if err != nil {
+		return "", err
+	}
+	return decimalPluralForm(tag, f, 3), nil
+}
+
+// pickPluralPair 从 "形式", "文本" 成对的参数中选择复数形式对应的文本，缺少时使用 other
+func pickPluralPair(form string, pairs []string) (string, error) {
+	if len(pairs)%2 != 0 {
+		return "", fmt.Errorf("gi18n: plural: expected form/text pairs, got %d arguments", len(pairs))
+	}
+	other, hasOther := "", false
+	for i := 0; i < len(pairs); i += 2 {
+		switch pairs[i] {
+		case form:
+			return pairs[i+1], nil
+		case "other":
+			other, hasOther = pairs[i+1], true
+		}
+	}
+	if !hasOther {
+		return "", fmt.Errorf("gi18n: plural: no text for form %q", form)
+	}
+	return other, nil
+}
+
+// errorType error 接口的反射类型
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// RegisterFunc 注册自定义模板函数，在所有消息模板中可用，同名时覆盖内置函数
+//
+//	bundle.RegisterFunc("shout", func(s string) string { return strings.ToUpper(s) + "!" })
+//	// "greeting": "Hello {{.Name | shout}}"
+//
+// 函数须满足 text/template 的要求：返回一个值，或一个值加 error；
+// 名称须为合法标识符。不满足时返回 ErrInvalidFunc
+func (b *Bundle) RegisterFunc(name string, fn interface{}) error {
+	if !isFuncName(name) {
+		return fmt.Errorf("%w: bad name %q", ErrInvalidFunc, name)
+	}
+	t := reflect.TypeOf(fn)
+	if t == nil || t.Kind() != reflect.Func {
+		return fmt.Errorf("%w: %q is not a function", ErrInvalidFunc, name)
+	}
+	switch {
+	case t.NumOut() == 1:
+	case t.NumOut() == 2 && t.Out(1) == errorType:
+	default:
+		return fmt.Errorf("%w: %q must return one value, or a value and an error", ErrInvalidFunc, name)
+	}
+
+	b.mu.Lock()
+	b.funcs[name] = fn
+	b.parsers.Store(&parserCache{})
+	b.mu.Unlock()
+	return nil
+}
+
+// isFuncName 判断是否为合法的模板函数名
+func isFuncName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		if r == '_' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r)) {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// catalogOverride 查找语言包中覆盖内置格式数据的消息
+// 只接受同一基础语言的翻译，避免回退语言的文本替代内置数据
+func (b *Bundle) catalogOverride(lang, id string, opts ...Option) (string, bool) {
+	r := b.localize(id, append([]Option{WithLang(lang)}, opts...))
+	if r.Err != nil || !sameBaseLang(r.MatchedLang, lang) {
+		return "", false
+	}
+	return r.Text, true
+}
+
+// sameBaseLang 判断两个语言标签的基础语言是否相同
+func sameBaseLang(a, b string) bool {
+	baseA, _ := parseLanguageTag(a).Base()
+	baseB, _ := parseLanguageTag(b).Base()
+	return baseA == baseB
+}
+
+// ========== 全局函数 ==========
+
+// RegisterFunc 注册自定义模板函数（全局）
+func RegisterFunc(name string, fn interface{}) error {
+	return Default().RegisterFunc(name, fn)
+}
@@ -13,6 +13,9 @@ package gi18n
 import (
 	"strings"
 	"sync"
+	"sync/atomic"
+	"text/template"
+	"time"
 
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"golang.org/x/text/language"
@@ -26,16 +29,22 @@ var (
 
 // Bundle 国际化包装器
 type Bundle struct {
-	bundle       *i18n.Bundle
-	mu           sync.RWMutex
-	localizers   sync.Map // map[string]*i18n.Localizer
-	currentLang  string
-	defaultLang  string
-	fallbackLang string
-	supported    []string
-	missHandler  func(lang, id string)
-	missPolicy   MissPolicy
-	logger       Logger
+	cat            atomic.Pointer[catalog]
+	loadMu         sync.Mutex // 串行化加载与快照操作
+	history        []*catalog
+	historySize    int
+	mu             sync.RWMutex
+	currentLang    string
+	defaultLang    string
+	fallbackLang   string
+	fallbackChains map[string][]string // 规范化语言 -> 回退链
+	defaultTag     language.Tag
+	watchInterval  time.Duration
+	missHandler    func(lang, id string)
+	missPolicy     MissPolicy
+	logger         Logger
+	funcs          template.FuncMap // 自定义模板函数
+	parsers        atomic.Pointer[parserCache]
 }
 
 // Config 初始化配置
@@ -43,6 +52,10 @@ type Config struct {
 	DefaultLang  string // 默认语言，默认 "en"
 	FallbackLang string // 回退语言，默认 "en"
 
+	// FallbackChains 各语言的回退链（可选），如 {"zh-HK": {"zh-TW", "zh-CN"}}
+	// 翻译时依次尝试: 请求语言 > 回退链 > 父语言（en-GB > en）> FallbackLang
+	FallbackChains map[string][]string
+
 	// MissHandler 翻译缺失回调（可选）
 	// 当翻译 key 不存在时触发，可用于日志记录或监控
 	MissHandler func(lang, id string)
@@ -52,6 +65,16 @@ type Config struct {
 
 	// Logger 日志接口（可选），兼容 slog/zap/logrus
 	Logger Logger
+
+	// WatchInterval Watch 轮询目录的间隔，默认 1 秒
+	WatchInterval time.Duration
+
+	// HistorySize Reload 时保留的历史快照数量，供 Rollback 使用，默认 5，负数表示不保留
+	HistorySize int
+
+	// Funcs 自定义模板函数（可选），在所有消息模板中可用，同名时覆盖内置函数
+	// 无效的函数会被忽略并通过 Logger 报告，见 Bundle.RegisterFunc
+	Funcs template.FuncMap
 }
 
 // Default 获取全局默认实例
@@ -69,6 +92,9 @@ func New(cfg *Config) *Bundle {
 	var missHandler func(lang, id string)
 	var missPolicy MissPolicy
 	var logger Logger
+	fallbackChains := make(map[string][]string)
+	watchInterval := defaultWatchInterval
+	historySize := defaultHistorySize
 
 	if cfg != nil {
 		if cfg.DefaultLang != "" {
@@ -80,22 +106,42 @@ func New(cfg *Config) *Bundle {
 		missHandler = cfg.MissHandler
 		missPolicy = cfg.MissPolicy
 		logger = cfg.Logger
+		for lang, chain := range cfg.FallbackChains {
+			fallbackChains[canonicalLang(lang)] = normalizeChain(chain)
+		}
+		if cfg.WatchInterval > 0 {
+			watchInterval = cfg.WatchInterval
+		}
+		if cfg.HistorySize != 0 {
+			historySize = cfg.HistorySize
+		}
 	}
 
 	tag := parseLanguageTag(defaultLang)
 
 	b := &Bundle{
-		bundle:       i18n.NewBundle(tag),
-		currentLang:  defaultLang,
-		defaultLang:  defaultLang,
-		fallbackLang: fallbackLang,
-		supported:    make([]string, 0),
-		missHandler:  missHandler,
-		missPolicy:   missPolicy,
-		logger:       logger,
+		historySize:    historySize,
+		currentLang:    defaultLang,
+		defaultLang:    defaultLang,
+		fallbackLang:   fallbackLang,
+		fallbackChains: fallbackChains,
+		defaultTag:     tag,
+		watchInterval:  watchInterval,
+		missHandler:    missHandler,
+		missPolicy:     missPolicy,
+		logger:         logger,
+		funcs:          make(template.FuncMap),
 	}
+	b.cat.Store(newCatalog(tag))
+	b.parsers.Store(&parserCache{})
 
-	b.registerUnmarshalers()
+	if cfg != nil {
+		for name, fn := range cfg.Funcs {
+			if err := b.RegisterFunc(name, fn); err != nil {
+				b.warn("gi18n: ignoring template function", "name", name, "error", err)
+			}
+		}
+	}
 	return b
 }
 
@@ -120,33 +166,21 @@ func normalizeLanguageTag(lang string) string {
 	return strings.ReplaceAll(lang, "_", "-")
 }
 
-// addSupported 添加支持的语言
-func (b *Bundle) addSupported(lang string) {
+// getLocalizer 获取翻译目录中指定语言的 Localizer（带缓存）
+func (b *Bundle) getLocalizer(c *catalog, lang string) *i18n.Localizer {
 	normalized := normalizeLanguageTag(lang)
-	for _, l := range b.supported {
-		if l == normalized {
-			return
-		}
-	}
-	b.supported = append(b.supported, normalized)
-}
 
-// getLocalizer 获取指定语言的 Localizer（带缓存）
-func (b *Bundle) getLocalizer(lang string) *i18n.Localizer {
-	normalized := normalizeLanguageTag(lang)
-
-	if loc, ok := b.localizers.Load(normalized); ok {
+	if loc, ok := c.localizers.Load(normalized); ok {
 		return loc.(*i18n.Localizer)
 	}
 
-	loc := i18n.NewLocalizer(b.bundle, normalized, b.fallbackLang)
-	b.localizers.Store(normalized, loc)
-	return loc
-}
+	b.mu.RLock()
+	fallbackLang := b.fallbackLang
+	b.mu.RUnlock()
 
-// clearLocalizerCache 清空 Localizer 缓存
-func (b *Bundle) clearLocalizerCache() {
-	b.localizers = sync.Map{}
+	loc := i18n.NewLocalizer(c.bundle, normalized, fallbackLang)
+	c.localizers.Store(normalized, loc)
+	return loc
 }
 
 // handleMiss 处理翻译缺失
@@ -161,5 +195,5 @@ func (b *Bundle) handleMiss(lang, id string) {
 
 // GetBundle 获取底层的 go-i18n Bundle（高级用法）
 func (b *Bundle) GetBundle() *i18n.Bundle {
-	return b.bundle
+	return b.cat.Load().bundle
 }
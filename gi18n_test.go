@@ -2,10 +2,18 @@ package gi18n
 
 import (
 	"context"
+	"errors"
+	"io"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"text/template"
+	"time"
 )
 
 // ========== 初始化测试 ==========
@@ -265,249 +273,2035 @@ func TestT_MissHandler(t *testing.T) {
 	}
 }
 
+// ========== Lookup 测试 ==========
+
+func TestLookup_Success(t *testing.T) {
+	b := New(nil)
+	_ = b.LoadMessages("en", map[string]string{"greeting": "Hello, {{.Name}}!"})
+
+	got, err := b.Lookup("greeting", WithData("Name", "Alice"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "Hello, Alice!" {
+		t.Errorf("expected 'Hello, Alice!', got '%s'", got)
+	}
+}
+
+func TestLookup_NotFound(t *testing.T) {
+	var missed bool
+	b := New(&Config{MissHandler: func(lang, id string) { missed = true }})
+
+	_, err := b.Lookup("nonexistent", WithLang("ja"))
+	if !errors.Is(err, ErrMessageNotFound) {
+		t.Fatalf("expected ErrMessageNotFound, got %v", err)
+	}
+	var nf *NotFoundError
+	if !errors.As(err, &nf) || nf.Lang != "ja" || nf.ID != "nonexistent" {
+		t.Errorf("unexpected NotFoundError: %+v", nf)
+	}
+	if missed {
+		t.Error("Lookup should not trigger MissHandler")
+	}
+}
+
+func TestLookup_EmptyID(t *testing.T) {
+	b := New(nil)
+	if _, err := b.Lookup(""); err != ErrEmptyID {
+		t.Errorf("expected ErrEmptyID, got %v", err)
+	}
+}
+
+func TestLookup_TemplateError(t *testing.T) {
+	b := New(nil)
+	_ = b.LoadMessages("en", map[string]string{"broken": "Hello, {{.Name"})
+
+	_, err := b.Lookup("broken", WithData("Name", "Alice"))
+	if !errors.Is(err, ErrTemplate) {
+		t.Fatalf("expected ErrTemplate, got %v", err)
+	}
+	if errors.Is(err, ErrMessageNotFound) {
+		t.Error("template error should not match ErrMessageNotFound")
+	}
+}
+
+func TestLookup_PluralError(t *testing.T) {
+	b := New(nil)
+	_ = b.LoadContent("en", "json", []byte(`{"items": {"one": "{{.Count}} item"}}`))
+
+	_, err := b.Lookup("items", WithCount(5))
+	if !errors.Is(err, ErrPlural) {
+		t.Fatalf("expected ErrPlural, got %v", err)
+	}
+	var pe *PluralError
+	if !errors.As(err, &pe) || pe.Count != 5 {
+		t.Errorf("unexpected PluralError: %+v", pe)
+	}
+}
+
+func TestLookup_TemplateErrorWithCount(t *testing.T) {
+	b := New(nil)
+	_ = b.LoadContent("en", "json", []byte(`{
+		"field": {"one": "{{.pluralName.X}}", "other": "{{.pluralName.X}}"},
+		"helper": {"one": "x", "other": "{{plural .Count \"one\" \"item\"}}"}
+	}`))
+
+	// 消息文本中出现 plural 不应被识别为复数错误
+	for _, id := range []string{"field", "helper"} {
+		_, err := b.Lookup(id, WithCount(3), WithData("pluralName", "s"))
+		if !errors.Is(err, ErrTemplate) {
+			t.Errorf("%s: expected ErrTemplate, got %v", id, err)
+		}
+		if errors.Is(err, ErrPlural) {
+			t.Errorf("%s: template error should not match ErrPlural", id)
+		}
+	}
+}
+
+func TestLoadContent_InvalidFormat(t *testing.T) {
+	b := New(nil)
+	if err := b.LoadContent("en", "xml", []byte("<a/>")); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("expected ErrInvalidFormat, got %v", err)
+	}
+}
+
+// ========== Resolve 测试 ==========
+
+func TestResolve_Matched(t *testing.T) {
+	b := New(nil)
+	_ = b.LoadMessages("en", map[string]string{"hello": "Hello"})
+	_ = b.LoadMessages("zh-CN", map[string]string{"hello": "你好"})
+
+	r := b.Resolve("hello", WithLang("zh_CN"))
+	if r.Text != "你好" || r.RequestedLang != "zh-CN" || r.MatchedLang != "zh-CN" {
+		t.Errorf("unexpected result: %+v", r)
+	}
+	if r.Fallback || r.Missing || r.Err != nil {
+		t.Errorf("expected no fallback and no error: %+v", r)
+	}
+}
+
+func TestResolve_Fallback(t *testing.T) {
+	b := New(nil)
+	_ = b.LoadMessages("en", map[string]string{"hello": "Hello"})
+
+	r := b.Resolve("hello", WithLang("ja"))
+	if r.Text != "Hello" || r.RequestedLang != "ja" || r.MatchedLang != "en" || !r.Fallback {
+		t.Errorf("unexpected result: %+v", r)
+	}
+}
+
+func TestResolve_Context(t *testing.T) {
+	b := New(nil)
+	_ = b.LoadMessages("zh-CN", map[string]string{"hello": "你好"})
+
+	ctx := ContextWithLang(context.Background(), "zh-CN")
+	if r := b.Resolve("hello", WithContext(ctx)); r.RequestedLang != "zh-CN" {
+		t.Errorf("expected requested 'zh-CN', got '%s'", r.RequestedLang)
+	}
+}
+
+func TestResolve_Missing(t *testing.T) {
+	var missed bool
+	b := New(&Config{MissHandler: func(lang, id string) { missed = true }})
+
+	r := b.Resolve("nonexistent")
+	if !r.Missing || r.Text != "nonexistent" || r.MatchedLang != "" {
+		t.Errorf("unexpected result: %+v", r)
+	}
+	if !errors.Is(r.Err, ErrMessageNotFound) {
+		t.Errorf("expected ErrMessageNotFound, got %v", r.Err)
+	}
+	if missed {
+		t.Error("Resolve should not trigger MissHandler")
+	}
+}
+
+func TestResolve_PluralForm(t *testing.T) {
+	b := New(nil)
+	_ = b.LoadContent("en", "json", []byte(`{"items": {"one": "{{.Count}} item", "other": "{{.Count}} items"}}`))
+
+	if r := b.Resolve("items", WithCount(1)); r.PluralForm != "one" {
+		t.Errorf("expected plural form 'one', got '%s'", r.PluralForm)
+	}
+	if r := b.Resolve("items", WithCount(3)); r.PluralForm != "other" {
+		t.Errorf("expected plural form 'other', got '%s'", r.PluralForm)
+	}
+	if r := b.Resolve("items"); r.PluralForm != "" {
+		t.Errorf("expected empty plural form, got '%s'", r.PluralForm)
+	}
+}
+
+// ========== 回退链测试 ==========
+
+func TestFallbackChain_Config(t *testing.T) {
+	b := New(&Config{
+		FallbackChains: map[string][]string{
+			"zh-HK": {"zh-TW", "zh-CN"},
+			"pt_BR": {"pt-PT"},
+		},
+	})
+	_ = b.LoadMessages("en", map[string]string{"a": "A-en", "b": "B-en", "c": "C-en"})
+	_ = b.LoadMessages("zh-CN", map[string]string{"a": "A-cn", "b": "B-cn"})
+	_ = b.LoadMessages("zh-TW", map[string]string{"a": "A-tw"})
+	_ = b.LoadMessages("pt-PT", map[string]string{"a": "A-pt"})
+
+	tests := []struct {
+		lang, id, expected string
+	}{
+		{"zh-HK", "a", "A-tw"},
+		{"zh-HK", "b", "B-cn"},
+		{"zh-HK", "c", "C-en"},
+		{"pt-BR", "a", "A-pt"},
+		{"pt-BR", "b", "B-en"},
+	}
+	for _, tt := range tests {
+		if got := b.T(tt.id, WithLang(tt.lang)); got != tt.expected {
+			t.Errorf("T(%q, %q) = %q, want %q", tt.id, tt.lang, got, tt.expected)
+		}
+	}
+
+	if r := b.Resolve("b", WithLang("zh-HK")); r.MatchedLang != "zh-CN" || !r.Fallback {
+		t.Errorf("unexpected result: %+v", r)
+	}
+}
+
+func TestFallbackChain_Parent(t *testing.T) {
+	b := New(&Config{FallbackLang: "ja"})
+	_ = b.LoadMessages("ja", map[string]string{"hello": "こんにちは"})
+	_ = b.LoadMessages("en", map[string]string{"hello": "Hello"})
+
+	if got := b.T("hello", WithLang("en-GB")); got != "Hello" {
+		t.Errorf("expected 'Hello', got '%s'", got)
+	}
+}
+
+func TestFallbackChain_Missing(t *testing.T) {
+	var missed string
+	b := New(&Config{MissHandler: func(lang, id string) { missed = lang }})
+	b.SetFallbackChain("zh-HK", "zh-TW")
+	_ = b.LoadMessages("zh-TW", map[string]string{"hello": "你好"})
+
+	if got := b.T("bye", WithLang("zh-HK")); got != "bye" {
+		t.Errorf("expected 'bye', got '%s'", got)
+	}
+	if missed != "zh-HK" {
+		t.Errorf("expected miss for 'zh-HK', got '%s'", missed)
+	}
+}
+
+func TestFallbackChain_List(t *testing.T) {
+	b := New(nil)
+	b.SetFallbackChain("zh_HK", "zh-TW", "zh-CN")
+
+	got := strings.Join(b.FallbackChain("zh-HK"), ",")
+	if got != "zh-HK,zh-TW,zh-CN,zh,en" {
+		t.Errorf("unexpected chain: %s", got)
+	}
+
+	b.SetFallbackChain("zh-HK")
+	if got := strings.Join(b.FallbackChain("zh-HK"), ","); got != "zh-HK,zh,en" {
+		t.Errorf("unexpected chain after reset: %s", got)
+	}
+}
+
+func TestFallbackChain_CacheInvalidation(t *testing.T) {
+	b := New(nil)
+	_ = b.LoadMessages("en", map[string]string{"hello": "Hello"})
+	_ = b.LoadMessages("zh-TW", map[string]string{"hello": "你好"})
+
+	if got := b.T("hello", WithLang("zh-HK")); got != "Hello" {
+		t.Errorf("expected 'Hello', got '%s'", got)
+	}
+
+	b.SetFallbackChain("zh-HK", "zh-TW")
+	if got := b.T("hello", WithLang("zh-HK")); got != "你好" {
+		t.Errorf("expected '你好' after SetFallbackChain, got '%s'", got)
+	}
+
+	_ = b.LoadMessages("zh-HK", map[string]string{"hello": "你好嗎"})
+	if got := b.T("hello", WithLang("zh-HK")); got != "你好嗎" {
+		t.Errorf("expected '你好嗎' after load, got '%s'", got)
+	}
+
+	b.SetFallbackChain("zh-HK")
+	b.SetFallbackLang("zh-TW")
+	if got := b.T("hello", WithLang("fr")); got != "你好" {
+		t.Errorf("expected '你好' after SetFallbackLang, got '%s'", got)
+	}
+}
+
+// ========== Translator 测试 ==========
+
+func TestTranslator(t *testing.T) {
+	b := New(nil)
+	_ = b.LoadMessages("en", map[string]string{"hello": "Hello", "greeting": "Hello, {{.Name}}!"})
+	_ = b.LoadMessages("zh-CN", map[string]string{"hello": "你好"})
+
+	tr := b.For("zh_CN")
+	if tr.Lang() != "zh-CN" {
+		t.Errorf("expected lang 'zh-CN', got '%s'", tr.Lang())
+	}
+	if got := tr.T("hello"); got != "你好" {
+		t.Errorf("expected '你好', got '%s'", got)
+	}
+	// 回退到 en
+	if got := tr.T("greeting", WithData("Name", "Bob")); got != "Hello, Bob!" {
+		t.Errorf("expected 'Hello, Bob!', got '%s'", got)
+	}
+	if _, err := tr.Lookup("missing"); !errors.Is(err, ErrMessageNotFound) {
+		t.Errorf("expected ErrMessageNotFound, got %v", err)
+	}
+	if !tr.Has("hello") || tr.Has("missing") || b.Has("") {
+		t.Error("unexpected Has result")
+	}
+
+	// 绑定语言后，全局语言变化不影响 Translator
+	b.SetLang("ja")
+	if got := tr.T("hello"); got != "你好" {
+		t.Errorf("expected '你好', got '%s'", got)
+	}
+}
+
+func TestTranslator_FromContext(t *testing.T) {
+	b := New(nil)
+	_ = b.LoadMessages("en", map[string]string{"hello": "Hello"})
+	_ = b.LoadMessages("ja", map[string]string{"hello": "こんにちは"})
+
+	ctx := ContextWithLang(context.Background(), "ja")
+	if got := b.FromContext(ctx).T("hello"); got != "こんにちは" {
+		t.Errorf("expected 'こんにちは', got '%s'", got)
+	}
+	if got := b.FromContext(context.Background()).Lang(); got != "en" {
+		t.Errorf("expected 'en', got '%s'", got)
+	}
+}
+
+func TestTranslator_FromContext_LangOverridesLocale(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+	b := New(nil)
+	_ = b.LoadMessages("en", map[string]string{"hello": "Hello"})
+	_ = b.LoadMessages("de", map[string]string{"hello": "Hallo"})
+
+	ctx := ContextWithLocale(context.Background(), Locale{Lang: "en-US", Region: "US", Location: tokyo})
+	ctx = ContextWithLang(ctx, "de")
+
+	l := b.FromContext(ctx).Locale()
+	if l.Lang != "de" || l.Region != "DE" || l.Currency.String() != "EUR" || l.Location != tokyo {
+		t.Errorf("unexpected locale: %+v", l)
+	}
+	if l := LocaleFromContext(ctx); l.Region != "DE" || l.Currency.String() != "EUR" {
+		t.Errorf("unexpected LocaleFromContext: %+v", l)
+	}
+}
+
+// ========== 数字格式化测试 ==========
+
+func TestFormatNumber(t *testing.T) {
+	b := New(nil)
+	tests := []struct {
+		lang     string
+		v        interface{}
+		opts     []NumberOption
+		expected string
+	}{
+		{"en", 1234567.5, nil, "1,234,567.5"},
+		{"de", 1234567.5, nil, "1.234.567,5"},
+		{"en", 1234567, nil, "1,234,567"},
+		{"en", 3.14159, []NumberOption{MaxFraction(2)}, "3.14"},
+		{"de", 2, []NumberOption{MinFraction(2)}, "2,00"},
+		{"en", 1.5, []NumberOption{Fraction(3)}, "1.500"},
+	}
+	for _, tt := range tests {
+		if got := b.FormatNumber(tt.lang, tt.v, tt.opts...); got != tt.expected {
+			t.Errorf("FormatNumber(%q, %v) = %q, want %q", tt.lang, tt.v, got, tt.expected)
+		}
+	}
+}
+
+func TestFormatPercent(t *testing.T) {
+	b := New(nil)
+	if got := b.FormatPercent("en", 0.256); got != "26%" {
+		t.Errorf("expected '26%%', got '%s'", got)
+	}
+	if got := b.FormatPercent("de", 0.256, MaxFraction(1)); got != "25,6\u00a0%" {
+		t.Errorf("expected '25,6\u00a0%%', got %q", got)
+	}
+}
+
+func TestNumber_TemplateFuncs(t *testing.T) {
+	b := New(nil)
+	_ = b.LoadMessages("en", map[string]string{"total": "Total: {{number .Amount}} ({{percent .Ratio}}, {{number .Rate 2}})"})
+	_ = b.LoadMessages("de", map[string]string{"total": "Summe: {{number .Amount}}"})
+
+	got := b.T("total", WithData("Amount", 1234567.5, "Ratio", 0.5, "Rate", 1.0))
+	if got != "Total: 1,234,567.5 (50%, 1.00)" {
+		t.Errorf("unexpected: %q", got)
+	}
+	if got := b.T("total", WithLang("de"), WithData("Amount", 1234567.5)); got != "Summe: 1.234.567,5" {
+		t.Errorf("unexpected: %q", got)
+	}
+	if got := b.For("de").FormatNumber(1234.5); got != "1.234,5" {
+		t.Errorf("unexpected: %q", got)
+	}
+}
+
+// ========== 货币格式化测试 ==========
+
+func TestFormatCurrency(t *testing.T) {
+	b := New(nil)
+	tests := []struct {
+		lang     string
+		amount   interface{}
+		code     string
+		display  []CurrencyDisplay
+		expected string
+	}{
+		{"en", 1234.5, "EUR", nil, "€1,234.50"},
+		{"de", 1234.5, "EUR", nil, "1.234,50\u00a0€"},
+		{"ja", 1234, "JPY", nil, "￥1,234"},
+		{"en", -5, "USD", nil, "-$5.00"},
+		{"en", 1234.5, "USD", []CurrencyDisplay{CurrencyCode}, "USD\u00a01,234.50"},
+		{"de", 1234.5, "usd", []CurrencyDisplay{CurrencyCode}, "1.234,50\u00a0USD"},
+		{"en-CA", 3, "USD", []CurrencyDisplay{CurrencyNarrow}, "$3.00"},
+		{"en", 3, "XYZ1", nil, "3 XYZ1"},
+	}
+	for _, tt := range tests {
+		if got := b.FormatCurrency(tt.lang, tt.amount, tt.code, tt.display...); got != tt.expected {
+			t.Errorf("FormatCurrency(%q, %v, %q) = %q, want %q", tt.lang, tt.amount, tt.code, got, tt.expected)
+		}
+	}
+}
+
+func TestCurrency_TemplateAndTranslator(t *testing.T) {
+	b := New(nil)
+	_ = b.LoadMessages("en", map[string]string{"price": "Price: {{currency .Amount}} / {{currency .Amount \"EUR\" \"code\"}}"})
+
+	got := b.T("price", WithLocale(NewLocale("en-US")), WithData("Amount", 9.9))
+	if got != "Price: $9.90 / EUR\u00a09.90" {
+		t.Errorf("unexpected: %q", got)
+	}
+	if got := b.ForLocale(NewLocale("de-DE")).FormatCurrency(9.9, ""); got != "9,90\u00a0€" {
+		t.Errorf("unexpected: %q", got)
+	}
+}
+
+// ========== 日期时间格式化测试 ==========
+
+func TestFormatDate(t *testing.T) {
+	b := New(nil)
+	tm := time.Date(2024, 3, 5, 14, 7, 9, 0, time.UTC)
+	tests := []struct {
+		lang     string
+		style    DateStyle
+		expected string
+	}{
+		{"en", DateShort, "3/5/24"},
+		{"en", DateMedium, "Mar 5, 2024"},
+		{"en", DateFull, "Tuesday, March 5, 2024"},
+		{"en-GB", DateShort, "05/03/2024"},
+		{"de", DateFull, "Dienstag, 5. März 2024"},
+		{"fr", DateLong, "5 mars 2024"},
+		{"es", DateLong, "5 de marzo de 2024"},
+		{"ru", DateLong, "5 марта 2024 г."},
+		{"zh-CN", DateFull, "2024年3月5日星期二"},
+		{"ja", DateFull, "2024年3月5日火曜日"},
+		{"xx", DateMedium, "Mar 5, 2024"},
+	}
+	for _, tt := range tests {
+		if got := b.FormatDate(tt.lang, tm, tt.style); got != tt.expected {
+			t.Errorf("FormatDate(%q, %d) = %q, want %q", tt.lang, tt.style, got, tt.expected)
+		}
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	b := New(nil)
+	tm := time.Date(2024, 3, 5, 14, 7, 9, 0, time.UTC)
+	shanghai := time.FixedZone("CST", 8*3600)
+
+	tests := []struct {
+		lang     string
+		style    DateStyle
+		opts     []DateOption
+		expected string
+	}{
+		{"en", DateShort, nil, "2:07 PM"},
+		{"de", DateShort, nil, "14:07"},
+		{"ko", DateShort, nil, "오후 2:07"},
+		{"en", DateLong, nil, "2:07:09 PM UTC"},
+		{"en", DateShort, []DateOption{Hour24()}, "14:07"},
+		{"de", DateShort, []DateOption{Hour12()}, "2:07 PM"},
+		{"zh-CN", DateMedium, []DateOption{InLocation(shanghai)}, "22:07:09"},
+	}
+	for _, tt := range tests {
+		if got := b.FormatTime(tt.lang, tm, tt.style, tt.opts...); got != tt.expected {
+			t.Errorf("FormatTime(%q, %d) = %q, want %q", tt.lang, tt.style, got, tt.expected)
+		}
+	}
+
+	if got := b.FormatDateTime("de", tm, DateLong); got != "5. März 2024 um 14:07:09" {
+		t.Errorf("unexpected: %q", got)
+	}
+}
+
+func TestDate_TemplateAndTimezone(t *testing.T) {
+	b := New(nil)
+	_ = b.LoadMessages("en", map[string]string{"sent": "Sent {{date .At \"long\"}} at {{time .At \"short\"}}"})
+	tm := time.Date(2024, 3, 5, 23, 30, 0, 0, time.UTC)
+	tokyo := time.FixedZone("JST", 9*3600)
+
+	l := NewLocale("en-US")
+	l.Location = tokyo
+	got := b.T("sent", WithLocale(l), WithData("At", tm))
+	if got != "Sent March 6, 2024 at 8:30 AM" {
+		t.Errorf("unexpected: %q", got)
+	}
+
+	ctx := ContextWithLocale(context.Background(), Locale{Lang: "de", Location: tokyo})
+	if got := b.FromContext(ctx).FormatTime(tm, DateShort); got != "08:30" {
+		t.Errorf("unexpected: %q", got)
+	}
+}
+
+// ========== 相对时间测试 ==========
+
+func TestRelativeTime(t *testing.T) {
+	b := New(nil)
+	tests := []struct {
+		lang     string
+		d        time.Duration
+		expected string
+	}{
+		{"en", -5 * time.Minute, "5 minutes ago"},
+		{"en", -time.Minute, "1 minute ago"},
+		{"en", 2 * 24 * time.Hour, "in 2 days"},
+		{"en", 500 * time.Millisecond, "now"},
+		{"zh-CN", -5 * time.Minute, "5分钟前"},
+		{"zh-CN", 3 * time.Hour, "3小时后"},
+		{"de", -5 * time.Minute, "vor 5 Minuten"},
+		{"de", -24 * time.Hour, "vor 1 Tag"},
+		{"ru", -2 * time.Hour, "2 часа назад"},
+		{"ru", -5 * time.Hour, "5 часов назад"},
+		{"fr", -21 * 24 * time.Hour, "il y a 3 semaines"},
+		{"xx", -400 * 24 * time.Hour, "1 year ago"},
+	}
+	for _, tt := range tests {
+		if got := b.RelativeTime(tt.lang, tt.d); got != tt.expected {
+			t.Errorf("RelativeTime(%q, %v) = %q, want %q", tt.lang, tt.d, got, tt.expected)
+		}
+	}
+}
+
+func TestRelativeTime_Override(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "en.json", `{
+		"gi18n.relative.minute.past": {"one": "a minute ago", "other": "{{.Count}} min ago"},
+		"gi18n.relative.now": "just now"
+	}`)
+	b := New(&Config{DefaultLang: "en", FallbackLang: "en"})
+	if err := b.Load(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := b.RelativeTime("en", -time.Minute); got != "a minute ago" {
+		t.Errorf("unexpected: %q", got)
+	}
+	if got := b.RelativeTime("en", -5*time.Minute); got != "5 min ago" {
+		t.Errorf("unexpected: %q", got)
+	}
+	if got := b.RelativeTime("en", 0); got != "just now" {
+		t.Errorf("unexpected: %q", got)
+	}
+	// 未覆盖的单位使用内置短语
+	if got := b.RelativeTime("en", -2*time.Hour); got != "2 hours ago" {
+		t.Errorf("unexpected: %q", got)
+	}
+	// 回退语言的覆盖不替代其它语言的内置短语
+	if got := b.RelativeTime("de", -5*time.Minute); got != "vor 5 Minuten" {
+		t.Errorf("unexpected: %q", got)
+	}
+}
+
+func TestRelativeTime_Template(t *testing.T) {
+	b := New(nil)
+	_ = b.LoadMessages("zh-CN", map[string]string{"updated": "更新于{{relative .Elapsed}}"})
+	if got := b.T("updated", WithLang("zh-CN"), WithData("Elapsed", -3*time.Minute)); got != "更新于3分钟前" {
+		t.Errorf("unexpected: %q", got)
+	}
+	if got := b.For("en").RelativeTime(90 * time.Second); got != "in 1 minute" {
+		t.Errorf("unexpected: %q", got)
+	}
+}
+
+// ========== 列表格式化测试 ==========
+
+func TestFormatList(t *testing.T) {
+	b := New(nil)
+	names := []string{"Alice", "Bob", "Carol"}
+	tests := []struct {
+		lang     string
+		items    []string
+		style    ListStyle
+		expected string
+	}{
+		{"en", names, ListAnd, "Alice, Bob, and Carol"},
+		{"en", names[:2], ListAnd, "Alice and Bob"},
+		{"en", names[:1], ListAnd, "Alice"},
+		{"en", nil, ListAnd, ""},
+		{"en", names, ListOr, "Alice, Bob, or Carol"},
+		{"zh-CN", names, ListAnd, "Alice、Bob和Carol"},
+		{"ja", names, ListOr, "Alice、Bob、またはCarol"},
+		{"de", names, ListAnd, "Alice, Bob und Carol"},
+		{"de", []string{"5 kg", "3 g", "1 mg"}, ListUnit, "5 kg, 3 g und 1 mg"},
+		{"xx", names[:2], ListOr, "Alice or Bob"},
+	}
+	for _, tt := range tests {
+		if got := b.FormatList(tt.lang, tt.items, tt.style); got != tt.expected {
+			t.Errorf("FormatList(%q, %v, %d) = %q, want %q", tt.lang, tt.items, tt.style, got, tt.expected)
+		}
+	}
+}
+
+func TestFormatList_OverrideAndTemplate(t *testing.T) {
+	b := New(nil)
+	_ = b.LoadContent("en", "json", []byte(`{
+		"gi18n.list.and.end": " and ",
+		"invite": "Invited {{list .Names}}; pick {{list .Names \"or\"}}"
+	}`))
+
+	got := b.T("invite", WithLang("en"), WithData("Names", []string{"Alice", "Bob", "Carol"}))
+	if got != "Invited Alice, Bob and Carol; pick Alice, Bob, or Carol" {
+		t.Errorf("unexpected: %q", got)
+	}
+	if got := b.For("zh-CN").FormatList([]string{"A", "B"}); got != "A和B" {
+		t.Errorf("unexpected: %q", got)
+	}
+}
+
+// ========== 紧凑数字和单位测试 ==========
+
+func TestFormatCompact(t *testing.T) {
+	b := New(nil)
+	tests := []struct {
+		lang     string
+		v        float64
+		style    UnitStyle
+		expected string
+	}{
+		{"en", 999, UnitShort, "999"},
+		{"en", 1234, UnitShort, "1.2K"},
+		{"en", 12345, UnitShort, "12K"},
+		{"en", 999999, UnitShort, "1M"},
+		{"en", 1234, UnitLong, "1.2 thousand"},
+		{"en", -2500000000, UnitShort, "-2.5B"},
+		{"zh-CN", 12345, UnitShort, "1.2万"},
+		{"zh-CN", 250000000, UnitShort, "2.5亿"},
+		{"ja", 12345, UnitShort, "1.2万"},
+		{"de", 1200000, UnitShort, "1,2\u00a0Mio."},
+		{"de", 1000000, UnitLong, "1 Million"},
+		{"de", 1200000, UnitLong, "1,2 Millionen"},
+		{"ru", 5000, UnitLong, "5 тысяч"},
+	}
+	for _, tt := range tests {
+		if got := b.FormatCompact(tt.lang, tt.v, tt.style); got != tt.expected {
+			t.Errorf("FormatCompact(%q, %v, %d) = %q, want %q", tt.lang, tt.v, tt.style, got, tt.expected)
+		}
+	}
+}
+
+func TestFormatUnits(t *testing.T) {
+	b := New(nil)
+	if got := b.FormatBytes("en", 3400000000); got != "3.4 GB" {
+		t.Errorf("unexpected: %q", got)
+	}
+	if got := b.FormatBytes("en", 999); got != "999 B" {
+		t.Errorf("unexpected: %q", got)
+	}
+	if got := b.FormatBytes("fr", 1500); got != "1,5 ko" {
+		t.Errorf("unexpected: %q", got)
+	}
+
+	d := 26*time.Hour + 90*time.Second
+	if got := b.FormatDuration("en", d, UnitShort); got != "1 day, 2 hr, 1 min, 30 sec" {
+		t.Errorf("unexpected: %q", got)
+	}
+	if got := b.FormatDuration("en", 90*time.Minute, UnitLong); got != "1 hour, 30 minutes" {
+		t.Errorf("unexpected: %q", got)
+	}
+	if got := b.FormatDuration("zh-CN", 90*time.Minute, UnitLong); got != "1小时30分钟" {
+		t.Errorf("unexpected: %q", got)
+	}
+	if got := b.FormatDuration("de", 0, UnitLong); got != "0 Sekunden" {
+		t.Errorf("unexpected: %q", got)
+	}
+
+	if got := b.FormatDistance("en", 850, UnitShort); got != "850 m" {
+		t.Errorf("unexpected: %q", got)
+	}
+	if got := b.FormatDistance("en", 1000, UnitLong); got != "1 kilometer" {
+		t.Errorf("unexpected: %q", got)
+	}
+	if got := b.FormatDistance("ru", 3400, UnitLong); got != "3,4 километра" {
+		t.Errorf("unexpected: %q", got)
+	}
+}
+
+func TestUnits_NonFinite(t *testing.T) {
+	b := New(nil)
+	// 超出 int64 范围、无穷大和 NaN 按 other 形式输出，不应 panic
+	for _, v := range []float64{1e31, -1e31, math.Inf(1), math.Inf(-1), math.NaN()} {
+		for _, lang := range []string{"en", "ru", "ar"} {
+			_ = b.FormatCompact(lang, v, UnitLong)
+			_ = b.FormatCompact(lang, v, UnitShort)
+			_ = b.FormatDistance(lang, v, UnitLong)
+		}
+	}
+	if got := b.FormatCompact("en", 1e31, UnitLong); !strings.HasSuffix(got, " trillion") {
+		t.Errorf("unexpected: %q", got)
+	}
+
+	_ = b.LoadMessages("en", map[string]string{
+		"plural":  "{{plural .Count \"one\" \"item\" \"other\" \"items\"}}",
+		"form":    "{{pluralForm .Count}}",
+		"compact": "{{compact .Count \"long\"}}",
+	})
+	for _, v := range []float64{1e31, math.Inf(1), math.NaN()} {
+		if got := b.T("plural", WithData("Count", v)); got != "items" {
+			t.Errorf("plural(%v) = %q, want 'items'", v, got)
+		}
+		if got := b.T("form", WithData("Count", v)); got != "other" {
+			t.Errorf("pluralForm(%v) = %q, want 'other'", v, got)
+		}
+		if _, err := b.Lookup("compact", WithData("Count", v)); err != nil {
+			t.Errorf("compact(%v): unexpected error %v", v, err)
+		}
+	}
+}
+
+func TestUnits_Template(t *testing.T) {
+	b := New(nil)
+	_ = b.LoadMessages("en", map[string]string{
+		"stats": "{{compact .Views}} views, {{bytes .Size}}, took {{duration .Elapsed \"long\"}}, {{distance .Meters}}",
+	})
+	got := b.T("stats", WithLang("en"), WithData("Views", 1234, "Size", int64(3400000000), "Elapsed", 90*time.Minute, "Meters", 3400))
+	if got != "1.2K views, 3.4 GB, took 1 hour, 30 minutes, 3.4 km" {
+		t.Errorf("unexpected: %q", got)
+	}
+}
+
+// ========== 模板函数测试 ==========
+
+func TestTemplateFuncs_Defaults(t *testing.T) {
+	b := New(nil)
+	_ = b.LoadMessages("en", map[string]string{
+		"upper":  "Hi {{.Name | upper}}",
+		"title":  "{{title .Name}}",
+		"plural": "{{.Count}} {{plural .Count \"one\" \"item\" \"other\" \"items\"}}",
+		"form":   "{{if eq (pluralForm .Count) \"one\"}}single{{else}}many{{end}}",
+	})
+	_ = b.LoadMessages("tr", map[string]string{"upper": "{{.Name | upper}}"})
+
+	if got := b.T("upper", WithData("Name", "alice")); got != "Hi ALICE" {
+		t.Errorf("unexpected: %q", got)
+	}
+	// 土耳其语的 i 大写为 İ
+	if got := b.T("upper", WithLang("tr"), WithData("Name", "istanbul")); got != "İSTANBUL" {
+		t.Errorf("unexpected: %q", got)
+	}
+	if got := b.T("title", WithData("Name", "hello world")); got != "Hello World" {
+		t.Errorf("unexpected: %q", got)
+	}
+	if got := b.T("plural", WithData("Count", 1)); got != "1 item" {
+		t.Errorf("unexpected: %q", got)
+	}
+	if got := b.T("plural", WithData("Count", 3)); got != "3 items" {
+		t.Errorf("unexpected: %q", got)
+	}
+	if got := b.T("form", WithData("Count", 1.5)); got != "many" {
+		t.Errorf("unexpected: %q", got)
+	}
+}
+
+func TestTemplateFuncs_Custom(t *testing.T) {
+	b := New(&Config{Funcs: template.FuncMap{
+		"shout": func(s string) string { return strings.ToUpper(s) + "!" },
+	}})
+	_ = b.LoadMessages("en", map[string]string{
+		"greeting": "Hello {{.Name | shout}}",
+		"price":    "{{number .Amount}}",
+	})
+
+	if got := b.T("greeting", WithData("Name", "bob")); got != "Hello BOB!" {
+		t.Errorf("unexpected: %q", got)
+	}
+	if got := b.T("price", WithData("Amount", 1234)); got != "1,234" {
+		t.Errorf("unexpected: %q", got)
+	}
+
+	// 注册的函数覆盖内置函数，已缓存的模板随之失效
+	if err := b.RegisterFunc("number", func(v interface{}) string { return "n/a" }); err != nil {
+		t.Fatal(err)
+	}
+	if got := b.T("price", WithData("Amount", 1234)); got != "n/a" {
+		t.Errorf("unexpected: %q", got)
+	}
+
+	for name, fn := range map[string]interface{}{
+		"":         func() string { return "" },
+		"bad-name": func() string { return "" },
+		"notFunc":  42,
+		"noOut":    func() {},
+		"badErr":   func() (string, string) { return "", "" },
+	} {
+		if err := b.RegisterFunc(name, fn); !errors.Is(err, ErrInvalidFunc) {
+			t.Errorf("RegisterFunc(%q): expected ErrInvalidFunc, got %v", name, err)
+		}
+	}
+}
+
+func TestTemplateFuncs_ParserCache(t *testing.T) {
+	b := New(nil)
+	_ = b.LoadMessages("en", map[string]string{"price": "{{number .Amount}}"})
+	_ = b.LoadMessages("de", map[string]string{"price": "{{number .Amount}}"})
+
+	for i := 0; i < 3; i++ {
+		if got := b.T("price", WithData("Amount", 1234.5)); got != "1,234.5" {
+			t.Errorf("unexpected: %q", got)
+		}
+		if got := b.T("price", WithLang("de"), WithData("Amount", 1234.5)); got != "1.234,5" {
+			t.Errorf("unexpected: %q", got)
+		}
+	}
+
+	if got := b.parsers.Load().size.Load(); got != 2 {
+		t.Errorf("expected 2 cached parsers, got %d", got)
+	}
+	p := b.templateParser(NewLocale("en"))
+	if p != b.templateParser(NewLocale("en")) {
+		t.Error("expected the parser to be reused for the same locale")
+	}
+	count := 0
+	p.templates.Range(func(_, _ interface{}) bool { count++; return true })
+	if count != 1 {
+		t.Errorf("expected 1 cached template, got %d", count)
+	}
+}
+
+func TestTemplateFuncs_InvalidConfig(t *testing.T) {
+	logger := &syncLogger{}
+	b := New(&Config{Logger: logger, Funcs: template.FuncMap{"broken": 1}})
+	_ = b.LoadMessages("en", map[string]string{"hello": "Hello"})
+
+	if got := b.T("hello"); got != "Hello" {
+		t.Errorf("unexpected: %q", got)
+	}
+	if logger.count() == 0 {
+		t.Error("expected a warning for the invalid function")
+	}
+}
+
 // ========== Logger 测试 ==========
 
-type testLogger struct {
-	warnings []string
+type testLogger struct {
+	warnings []string
+}
+
+func (l *testLogger) Warn(msg string, args ...any) {
+	l.warnings = append(l.warnings, msg)
+}
+
+func TestLogger(t *testing.T) {
+	logger := &testLogger{}
+	b := New(&Config{Logger: logger})
+
+	b.T("nonexistent")
+
+	if len(logger.warnings) != 1 {
+		t.Errorf("expected 1 warning, got %d", len(logger.warnings))
+	}
+	if len(logger.warnings) > 0 && !strings.Contains(logger.warnings[0], "missing translation") {
+		t.Errorf("expected warning about missing translation, got '%s'", logger.warnings[0])
+	}
+}
+
+func TestLogger_NotCalledOnSuccess(t *testing.T) {
+	logger := &testLogger{}
+	b := New(&Config{Logger: logger})
+	_ = b.LoadMessages("en", map[string]string{"hello": "Hello"})
+
+	b.T("hello")
+
+	if len(logger.warnings) != 0 {
+		t.Errorf("expected 0 warnings on success, got %d", len(logger.warnings))
+	}
+}
+
+// ========== 加载测试 ==========
+
+func TestLoadMessages(t *testing.T) {
+	b := New(nil)
+	err := b.LoadMessages("en", map[string]string{
+		"hello": "Hello",
+		"world": "World",
+	})
+	if err != nil {
+		t.Fatalf("LoadMessages failed: %v", err)
+	}
+
+	if got := b.T("hello"); got != "Hello" {
+		t.Errorf("expected 'Hello', got '%s'", got)
+	}
+	if got := b.T("world"); got != "World" {
+		t.Errorf("expected 'World', got '%s'", got)
+	}
+}
+
+func TestLoadContent_JSON(t *testing.T) {
+	b := New(nil)
+	data := []byte(`{"hello": "你好", "world": "世界"}`)
+	err := b.LoadContent("zh-CN", "json", data)
+	if err != nil {
+		t.Fatalf("LoadContent failed: %v", err)
+	}
+
+	if got := b.T("hello", WithLang("zh-CN")); got != "你好" {
+		t.Errorf("expected '你好', got '%s'", got)
+	}
+}
+
+func TestLoadContent_Nested(t *testing.T) {
+	b := New(nil)
+	data := []byte(`{
+		"common": {
+			"confirm": "确定",
+			"cancel": "取消"
+		},
+		"user": {
+			"profile": {
+				"title": "个人资料"
+			}
+		}
+	}`)
+	err := b.LoadContent("zh-CN", "json", data)
+	if err != nil {
+		t.Fatalf("LoadContent failed: %v", err)
+	}
+
+	tests := map[string]string{
+		"common.confirm":     "确定",
+		"common.cancel":      "取消",
+		"user.profile.title": "个人资料",
+	}
+
+	for key, expected := range tests {
+		if got := b.T(key, WithLang("zh-CN")); got != expected {
+			t.Errorf("T(%q) = %q, want %q", key, got, expected)
+		}
+	}
+}
+
+func TestLoadContent_YAML(t *testing.T) {
+	b := New(nil)
+	data := []byte("hello: 你好\nworld: 世界\n")
+	err := b.LoadContent("zh-CN", "yaml", data)
+	if err != nil {
+		t.Fatalf("LoadContent YAML failed: %v", err)
+	}
+
+	if got := b.T("hello", WithLang("zh-CN")); got != "你好" {
+		t.Errorf("expected '你好', got '%s'", got)
+	}
+}
+
+func TestLoadContent_Plural(t *testing.T) {
+	b := New(nil)
+	data := []byte(`{
+		"items": {
+			"one": "{{.Count}} item",
+			"other": "{{.Count}} items"
+		}
+	}`)
+	err := b.LoadContent("en", "json", data)
+	if err != nil {
+		t.Fatalf("LoadContent failed: %v", err)
+	}
+
+	if got := b.T("items", WithCount(1)); got != "1 item" {
+		t.Errorf("expected '1 item', got '%s'", got)
+	}
+	if got := b.T("items", WithCount(3)); got != "3 items" {
+		t.Errorf("expected '3 items', got '%s'", got)
+	}
+}
+
+// ========== 热更新测试 ==========
+
+// syncLogger 并发安全的 Logger，供后台 goroutine 使用
+type syncLogger struct {
+	mu       sync.Mutex
+	warnings []string
+}
+
+func (l *syncLogger) Warn(msg string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.warnings = append(l.warnings, msg)
+}
+
+func (l *syncLogger) count() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.warnings)
+}
+
+// waitFor 在超时前轮询条件
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("condition not met before timeout")
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWatch_Reload(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "en.json", `{"hello": "Hello"}`)
+
+	b := New(&Config{WatchInterval: 10 * time.Millisecond})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := b.Watch(ctx, dir); err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	if got := b.T("hello"); got != "Hello" {
+		t.Fatalf("expected 'Hello', got '%s'", got)
+	}
+
+	// 修改
+	writeFile(t, dir, "en.json", `{"hello": "Hello, world"}`)
+	waitFor(t, func() bool { return b.T("hello") == "Hello, world" })
+
+	// 新增
+	writeFile(t, dir, "zh-CN.yaml", "hello: 你好\n")
+	waitFor(t, func() bool { return b.T("hello", WithLang("zh-CN")) == "你好" })
+
+	// 删除
+	if err := os.Remove(filepath.Join(dir, "zh-CN.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return len(b.Languages()) == 1 })
+}
+
+func TestWatch_KeepPreviousOnError(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "en.json", `{"hello": "Hello"}`)
+
+	logger := &syncLogger{}
+	b := New(&Config{Logger: logger, WatchInterval: 10 * time.Millisecond})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := b.Watch(ctx, dir); err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+
+	writeFile(t, dir, "en.json", `{"hello": `)
+	waitFor(t, func() bool { return logger.count() > 0 })
+
+	if got := b.T("hello"); got != "Hello" {
+		t.Errorf("expected previous 'Hello', got '%s'", got)
+	}
+}
+
+func TestWatch_KeepsOtherSources(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "en.json", `{"hello": "Hello"}`)
+
+	b := New(&Config{WatchInterval: 10 * time.Millisecond})
+	_ = b.LoadMessages("en", map[string]string{"bye": "Bye"})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := b.Watch(ctx, dir); err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+
+	writeFile(t, dir, "en.json", `{"hello": "Hi"}`)
+	waitFor(t, func() bool { return b.T("hello") == "Hi" })
+
+	if got := b.T("bye"); got != "Bye" {
+		t.Errorf("expected 'Bye', got '%s'", got)
+	}
+}
+
+func TestWatch_MissingDir(t *testing.T) {
+	b := New(nil)
+	if err := b.Watch(context.Background(), filepath.Join(t.TempDir(), "none")); err == nil {
+		t.Error("expected error for missing directory")
+	}
+}
+
+// ========== 原子重载与回滚测试 ==========
+
+func TestLoad_FailureKeepsCatalog(t *testing.T) {
+	b := New(nil)
+	_ = b.LoadMessages("en", map[string]string{"hello": "Hello"})
+
+	if err := b.LoadContent("en", "json", []byte(`{"hello": `)); err == nil {
+		t.Fatal("expected parse error")
+	}
+	if got := b.T("hello"); got != "Hello" {
+		t.Errorf("expected 'Hello', got '%s'", got)
+	}
+	// 加载方法不产生快照
+	if got := b.Snapshots(); got != 0 {
+		t.Errorf("expected 0 snapshots, got %d", got)
+	}
+}
+
+func TestLoad_DoesNotRereadEarlierSources(t *testing.T) {
+	dirA, dirB := t.TempDir(), t.TempDir()
+	writeFile(t, dirA, "en.json", `{"a": "A"}`)
+	writeFile(t, dirB, "en.json", `{"b": "B"}`)
+
+	b := New(nil)
+	if err := b.Load(dirA); err != nil {
+		t.Fatal(err)
+	}
+	// 已加载目录不可读时，加载其它来源不受影响
+	if err := os.RemoveAll(dirA); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Load(dirB); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if got := b.T("a"); got != "A" {
+		t.Errorf("expected 'A', got '%s'", got)
+	}
+	if got := b.T("b"); got != "B" {
+		t.Errorf("expected 'B', got '%s'", got)
+	}
+}
+
+func TestLoadMessages_CopiesMap(t *testing.T) {
+	b := New(nil)
+	msgs := map[string]string{"hello": "Hello"}
+	_ = b.LoadMessages("en", msgs)
+
+	msgs["hello"] = "Changed"
+	if err := b.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if got := b.T("hello"); got != "Hello" {
+		t.Errorf("expected 'Hello', got '%s'", got)
+	}
+}
+
+func TestRollback(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "en.json", `{"hello": "Hello"}`)
+
+	b := New(nil)
+	if err := b.Load(dir); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, dir, "en.json", `{"hello": "Hi"}`)
+	if err := b.Reload(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if got := b.T("hello"); got != "Hi" {
+		t.Fatalf("expected 'Hi', got '%s'", got)
+	}
+
+	if err := b.Rollback(); err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+	if got := b.T("hello"); got != "Hello" {
+		t.Errorf("expected 'Hello' after rollback, got '%s'", got)
+	}
+	if err := b.Rollback(); err != ErrNoSnapshot {
+		t.Errorf("expected ErrNoSnapshot, got %v", err)
+	}
+}
+
+func TestRollback_NoSnapshot(t *testing.T) {
+	b := New(&Config{HistorySize: -1})
+	_ = b.LoadMessages("en", map[string]string{"hello": "Hello"})
+	_ = b.Reload()
+
+	if err := b.Rollback(); err != ErrNoSnapshot {
+		t.Errorf("expected ErrNoSnapshot, got %v", err)
+	}
+}
+
+func TestRollback_HistorySize(t *testing.T) {
+	b := New(&Config{HistorySize: 2})
+	_ = b.LoadMessages("en", map[string]string{"hello": "Hello"})
+	for i := 0; i < 5; i++ {
+		_ = b.Reload()
+	}
+	if got := b.Snapshots(); got != 2 {
+		t.Errorf("expected 2 snapshots, got %d", got)
+	}
+}
+
+func TestReload_Concurrent(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "en.json", `{"a": "A", "b": "B"}`)
+
+	b := New(nil)
+	if err := b.Load(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				if b.T("a") != "A" || b.T("b") != "B" {
+					t.Error("observed a half-loaded catalog")
+					return
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 20; i++ {
+		if err := b.Reload(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	close(stop)
+	wg.Wait()
+}
+
+// ========== Context 测试 ==========
+
+func TestContextWithLang(t *testing.T) {
+	ctx := ContextWithLang(context.Background(), "zh-CN")
+	lang := LangFromContext(ctx)
+	if lang != "zh-CN" {
+		t.Errorf("expected 'zh-CN', got '%s'", lang)
+	}
+}
+
+func TestContextWithLang_Normalize(t *testing.T) {
+	ctx := ContextWithLang(context.Background(), "zh_TW")
+	lang := LangFromContext(ctx)
+	if lang != "zh-TW" {
+		t.Errorf("expected 'zh-TW', got '%s'", lang)
+	}
+}
+
+func TestLangFromContext_NoLang(t *testing.T) {
+	// 确保全局实例已初始化
+	Init(&Config{DefaultLang: "en"})
+	lang := LangFromContext(context.Background())
+	if lang != "en" {
+		t.Errorf("expected 'en', got '%s'", lang)
+	}
+}
+
+func TestContextWithBundle(t *testing.T) {
+	Init(&Config{DefaultLang: "en"})
+	b := New(&Config{DefaultLang: "ja"})
+
+	ctx := ContextWithBundle(context.Background(), b)
+	if BundleFromContext(ctx) != b {
+		t.Error("expected bundle from context")
+	}
+	if BundleFromContext(context.Background()) != Default() {
+		t.Error("expected global bundle without bundle in context")
+	}
+	if got := LangFromContext(ctx); got != "ja" {
+		t.Errorf("expected 'ja', got '%s'", got)
+	}
+}
+
+// ========== Locale 测试 ==========
+
+func TestNewLocale(t *testing.T) {
+	l := NewLocale("de")
+	if l.Lang != "de" || l.Region != "DE" || l.Currency.String() != "EUR" || l.Location != time.Local {
+		t.Errorf("unexpected locale: %+v", l)
+	}
+	if got := NewLocale("zh_CN").Currency.String(); got != "CNY" {
+		t.Errorf("expected 'CNY', got '%s'", got)
+	}
+}
+
+func TestContextWithLocale(t *testing.T) {
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+
+	ctx := ContextWithLocale(context.Background(), Locale{Lang: "en", Region: "GB", Location: shanghai})
+	l := LocaleFromContext(ctx)
+	if l.Lang != "en" || l.Region != "GB" || l.Location != shanghai || l.Currency.String() != "GBP" {
+		t.Errorf("unexpected locale: %+v", l)
+	}
+	if got := LangFromContext(ctx); got != "en" {
+		t.Errorf("expected lang 'en', got '%s'", got)
+	}
+	if got := l.Tag().String(); got != "en-GB" {
+		t.Errorf("expected tag 'en-GB', got '%s'", got)
+	}
+
+	// 仅有语言时由语言推导
+	l = LocaleFromContext(ContextWithLang(context.Background(), "ja"))
+	if l.Region != "JP" || l.Currency.String() != "JPY" {
+		t.Errorf("unexpected derived locale: %+v", l)
+	}
+}
+
+func TestMiddleware_Locale(t *testing.T) {
+	if _, err := time.LoadLocation("Europe/Berlin"); err != nil {
+		t.Skip("time zone database not available")
+	}
+	b := New(nil)
+	_ = b.LoadMessages("en", map[string]string{"hello": "Hello"})
+	_ = b.LoadMessages("de", map[string]string{"hello": "Hallo"})
+
+	cfg := DefaultMiddlewareConfig()
+	cfg.Bundle = b
+	cfg.TimezoneHeader = "X-Timezone"
+	cfg.CurrencyCookie = "currency"
+	cfg.RegionHeader = "X-Region"
+
+	var got Locale
+	var tr *Translator
+	handler := Middleware(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = LocaleFromContext(r.Context())
+		tr = FromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest("GET", "/?lang=de", nil)
+	req.Header.Set("X-Timezone", "Europe/Berlin")
+	req.Header.Set("X-Region", "at")
+	req.AddCookie(&http.Cookie{Name: "currency", Value: "usd"})
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if got.Lang != "de" || got.Region != "AT" || got.Location.String() != "Europe/Berlin" || got.Currency.String() != "USD" {
+		t.Errorf("unexpected locale: %+v", got)
+	}
+	if tr.Locale().Location.String() != "Europe/Berlin" || tr.T("hello") != "Hallo" {
+		t.Errorf("unexpected translator locale: %+v", tr.Locale())
+	}
+}
+
+func TestMiddleware_LocaleVary(t *testing.T) {
+	Init(&Config{DefaultLang: "en"})
+
+	cfg := &MiddlewareConfig{
+		Sources:        []LangSource{SourceQuery},
+		QueryParam:     "lang",
+		Vary:           true,
+		RegionHeader:   "x-region",
+		TimezoneHeader: "X-Timezone",
+		CurrencyCookie: "currency",
+	}
+	handler := Middleware(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+	if got := strings.Join(w.Header().Values("Vary"), ", "); got != "X-Region, X-Timezone, Cookie" {
+		t.Errorf("unexpected Vary: '%s'", got)
+	}
+}
+
+// ========== Middleware 测试 ==========
+
+func TestMiddleware_QueryParam(t *testing.T) {
+	Init(&Config{DefaultLang: "en"})
+	_ = LoadMessages("en", map[string]string{"hello": "Hello"})
+	_ = LoadMessages("zh-CN", map[string]string{"hello": "你好"})
+
+	handler := Middleware(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		msg := T("hello", WithContext(r.Context()))
+		w.Write([]byte(msg))
+	}))
+
+	req := httptest.NewRequest("GET", "/?lang=zh-CN", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if got := w.Body.String(); got != "你好" {
+		t.Errorf("expected '你好', got '%s'", got)
+	}
+}
+
+func TestMiddleware_AcceptLanguage(t *testing.T) {
+	Init(&Config{DefaultLang: "en"})
+	_ = LoadMessages("en", map[string]string{"hello": "Hello"})
+	_ = LoadMessages("zh-CN", map[string]string{"hello": "你好"})
+
+	handler := Middleware(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		msg := T("hello", WithContext(r.Context()))
+		w.Write([]byte(msg))
+	}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Language", "zh-CN,zh;q=0.9,en;q=0.8")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if got := w.Body.String(); got != "你好" {
+		t.Errorf("expected '你好', got '%s'", got)
+	}
+}
+
+func TestMiddleware_Cookie(t *testing.T) {
+	Init(&Config{DefaultLang: "en"})
+	_ = LoadMessages("en", map[string]string{"hello": "Hello"})
+	_ = LoadMessages("zh-CN", map[string]string{"hello": "你好"})
+
+	handler := Middleware(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		msg := T("hello", WithContext(r.Context()))
+		w.Write([]byte(msg))
+	}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.AddCookie(&http.Cookie{Name: "lang", Value: "zh-CN"})
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if got := w.Body.String(); got != "你好" {
+		t.Errorf("expected '你好', got '%s'", got)
+	}
+}
+
+func TestMiddleware_CustomConfig(t *testing.T) {
+	Init(&Config{DefaultLang: "en"})
+	_ = LoadMessages("en", map[string]string{"hello": "Hello"})
+	_ = LoadMessages("zh-CN", map[string]string{"hello": "你好"})
+
+	cfg := &MiddlewareConfig{
+		Sources:    []LangSource{SourceQuery},
+		QueryParam: "locale",
+	}
+
+	handler := Middleware(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		msg := T("hello", WithContext(r.Context()))
+		w.Write([]byte(msg))
+	}))
+
+	req := httptest.NewRequest("GET", "/?locale=zh-CN", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if got := w.Body.String(); got != "你好" {
+		t.Errorf("expected '你好', got '%s'", got)
+	}
+}
+
+func TestMiddleware_Negotiate(t *testing.T) {
+	Init(&Config{DefaultLang: "en"})
+	_ = LoadMessages("en", map[string]string{"hello": "Hello"})
+	_ = LoadMessages("zh-CN", map[string]string{"hello": "你好"})
+
+	handler := Middleware(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(LangFromContext(r.Context())))
+	}))
+
+	// 未加载的语言跳过，继续尝试下一个来源
+	req := httptest.NewRequest("GET", "/?lang=xx", nil)
+	req.Header.Set("Accept-Language", "zh-Hans-CN;q=0.9, en;q=0.5")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if got := w.Body.String(); got != "zh-CN" {
+		t.Errorf("expected 'zh-CN', got '%s'", got)
+	}
+}
+
+func TestMiddleware_AcceptLanguageInTurn(t *testing.T) {
+	Init(&Config{DefaultLang: "en"})
+	_ = LoadMessages("en", map[string]string{"hello": "Hello"})
+	_ = LoadMessages("de", map[string]string{"hello": "Hallo"})
+
+	handler := Middleware(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(LangFromContext(r.Context())))
+	}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Language", "*;q=0.1, fr;q=0.9, de;q=0.5")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if got := w.Body.String(); got != "de" {
+		t.Errorf("expected 'de', got '%s'", got)
+	}
+}
+
+func TestMiddleware_ResponseHeaders(t *testing.T) {
+	Init(&Config{DefaultLang: "en"})
+	_ = LoadMessages("en", map[string]string{"hello": "Hello"})
+	_ = LoadMessages("zh-CN", map[string]string{"hello": "你好"})
+
+	cfg := DefaultMiddlewareConfig()
+	cfg.ContentLanguage = true
+	cfg.Vary = true
+	handler := Middleware(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Origin")
+	}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Language", "zh-CN")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Content-Language"); got != "zh-CN" {
+		t.Errorf("expected Content-Language 'zh-CN', got '%s'", got)
+	}
+	if got := strings.Join(w.Header().Values("Vary"), ", "); got != "Cookie, Accept-Language, Origin" {
+		t.Errorf("unexpected Vary: '%s'", got)
+	}
+}
+
+func TestMiddleware_ResponseHeaders_DefaultOff(t *testing.T) {
+	Init(&Config{DefaultLang: "en"})
+	_ = LoadMessages("en", map[string]string{"hello": "Hello"})
+
+	handler := Middleware(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/?lang=en", nil))
+
+	if got := w.Header().Get("Content-Language"); got != "" {
+		t.Errorf("expected no Content-Language by default, got '%s'", got)
+	}
+	if got := w.Header().Values("Vary"); len(got) != 0 {
+		t.Errorf("expected no Vary by default, got %v", got)
+	}
+}
+
+func TestMiddleware_ResponseHeaders_Disabled(t *testing.T) {
+	Init(&Config{DefaultLang: "en"})
+
+	cfg := &MiddlewareConfig{
+		Sources:    []LangSource{SourceQuery, SourceHeader},
+		QueryParam: "lang",
+		Vary:       true,
+	}
+	handler := Middleware(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	w := httptest.NewRecorder()
+	w.Header().Set("Vary", "accept-language")
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+	if got := w.Header().Get("Content-Language"); got != "" {
+		t.Errorf("expected no Content-Language, got '%s'", got)
+	}
+	if got := w.Header().Values("Vary"); len(got) != 1 {
+		t.Errorf("expected Vary not duplicated, got %v", got)
+	}
+}
+
+func TestMiddleware_PersistCookie(t *testing.T) {
+	Init(&Config{DefaultLang: "en"})
+	_ = LoadMessages("en", map[string]string{"hello": "Hello"})
+	_ = LoadMessages("ja", map[string]string{"hello": "こんにちは"})
+
+	cfg := DefaultMiddlewareConfig()
+	cfg.ContentLanguage = true
+	cfg.PersistCookie = true
+	cfg.CookieDomain = "example.com"
+	cfg.CookieSecure = true
+	handler := Middleware(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/?lang=ja", nil))
+
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 {
+		t.Fatalf("expected 1 cookie, got %d", len(cookies))
+	}
+	c := cookies[0]
+	if c.Name != "lang" || c.Value != "ja" || c.Path != "/" || c.Domain != "example.com" ||
+		!c.Secure || c.MaxAge != 365*24*3600 || c.SameSite != http.SameSiteLaxMode {
+		t.Errorf("unexpected cookie: %+v", c)
+	}
+
+	// 下一次请求仅携带 Cookie，语言保持
+	req := httptest.NewRequest("GET", "/", nil)
+	req.AddCookie(c)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if got := w.Header().Get("Content-Language"); got != "ja" {
+		t.Errorf("expected 'ja', got '%s'", got)
+	}
+	if len(w.Result().Cookies()) != 0 {
+		t.Error("cookie should not be rewritten when not from query")
+	}
+}
+
+func TestMiddleware_PersistCookie_Disabled(t *testing.T) {
+	Init(&Config{DefaultLang: "en"})
+	_ = LoadMessages("ja", map[string]string{"hello": "こんにちは"})
+
+	handler := Middleware(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/?lang=ja", nil))
+
+	if len(w.Result().Cookies()) != 0 {
+		t.Error("expected no cookie by default")
+	}
+}
+
+func TestMiddleware_PathPrefix(t *testing.T) {
+	Init(&Config{DefaultLang: "en"})
+	_ = LoadMessages("en", map[string]string{"hello": "Hello"})
+	_ = LoadMessages("zh-CN", map[string]string{"hello": "你好"})
+
+	cfg := DefaultMiddlewareConfig()
+	cfg.Sources = []LangSource{SourcePath, SourceHeader}
+	handler := Middleware(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(LangFromContext(r.Context()) + " " + r.URL.Path))
+	}))
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"/zh-CN/products", "zh-CN /products"},
+		{"/zh-cn", "zh-CN /"},
+		{"/products", "en /products"},
+		{"/fr/products", "en /fr/products"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", tt.path, nil))
+		if got := w.Body.String(); got != tt.expected {
+			t.Errorf("%s: expected '%s', got '%s'", tt.path, tt.expected, got)
+		}
+	}
+}
+
+func TestMiddleware_Host(t *testing.T) {
+	Init(&Config{DefaultLang: "en"})
+	_ = LoadMessages("en", map[string]string{"hello": "Hello"})
+	_ = LoadMessages("de", map[string]string{"hello": "Hallo"})
+	_ = LoadMessages("fr", map[string]string{"hello": "Bonjour"})
+
+	cfg := DefaultMiddlewareConfig()
+	cfg.Sources = []LangSource{SourceQuery, SourceHost, SourceHeader}
+	cfg.HostLangs = map[string]string{"example.fr": "fr"}
+	cfg.HostSubdomain = true
+	handler := Middleware(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(LangFromContext(r.Context())))
+	}))
+
+	tests := []struct {
+		url      string
+		expected string
+	}{
+		{"http://de.example.com/", "de"},
+		{"http://EXAMPLE.fr:8080/", "fr"},
+		{"http://www.example.com/", "en"},
+		{"http://de.example.com/?lang=fr", "fr"},
+		{"http://example.com/", "en"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", tt.url, nil))
+		if got := w.Body.String(); got != tt.expected {
+			t.Errorf("%s: expected '%s', got '%s'", tt.url, tt.expected, got)
+		}
+	}
 }
 
-func (l *testLogger) Warn(msg string, args ...any) {
-	l.warnings = append(l.warnings, msg)
+func TestMiddleware_CustomSources(t *testing.T) {
+	Init(&Config{DefaultLang: "en"})
+	_ = LoadMessages("en", map[string]string{"hello": "Hello"})
+	_ = LoadMessages("ja", map[string]string{"hello": "こんにちは"})
+	_ = LoadMessages("de", map[string]string{"hello": "Hallo"})
+
+	profile := RegisterSource(func(r *http.Request) string {
+		if r.Header.Get("X-User") == "42" {
+			return "de"
+		}
+		return ""
+	})
+	cfg := DefaultMiddlewareConfig()
+	cfg.Sources = []LangSource{profile, HeaderSource("x-app-locale"), FormSource("locale"), SourceHeader}
+	handler := Middleware(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(LangFromContext(r.Context())))
+	}))
+
+	tests := []struct {
+		name     string
+		build    func(r *http.Request)
+		url      string
+		expected string
+	}{
+		{"profile", func(r *http.Request) { r.Header.Set("X-User", "42") }, "/", "de"},
+		{"header", func(r *http.Request) { r.Header.Set("X-App-Locale", "ja") }, "/", "ja"},
+		{"form", func(r *http.Request) {}, "/?locale=ja", "ja"},
+		{"fallthrough", func(r *http.Request) { r.Header.Set("Accept-Language", "de") }, "/", "de"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", tt.url, nil)
+		tt.build(req)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if got := w.Body.String(); got != tt.expected {
+			t.Errorf("%s: expected '%s', got '%s'", tt.name, tt.expected, got)
+		}
+	}
+
+	if HeaderSource("X-App-Locale") != HeaderSource("x-app-locale") {
+		t.Error("HeaderSource should be registered once per header")
+	}
 }
 
-func TestLogger(t *testing.T) {
-	logger := &testLogger{}
-	b := New(&Config{Logger: logger})
+func TestMiddleware_HeaderSourceVary(t *testing.T) {
+	Init(&Config{DefaultLang: "en"})
 
-	b.T("nonexistent")
+	cfg := DefaultMiddlewareConfig()
+	cfg.Sources = []LangSource{HeaderSource("x-app-locale"), FormSource("locale"), SourceHeader}
+	cfg.Vary = true
+	handler := Middleware(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
 
-	if len(logger.warnings) != 1 {
-		t.Errorf("expected 1 warning, got %d", len(logger.warnings))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+	if got := strings.Join(w.Header().Values("Vary"), ", "); got != "X-App-Locale, Accept-Language" {
+		t.Errorf("unexpected Vary: '%s'", got)
 	}
-	if len(logger.warnings) > 0 && !strings.Contains(logger.warnings[0], "missing translation") {
-		t.Errorf("expected warning about missing translation, got '%s'", logger.warnings[0])
+}
+
+func TestMiddleware_Bundle(t *testing.T) {
+	Init(&Config{DefaultLang: "en"})
+	b := New(&Config{DefaultLang: "en"})
+	_ = b.LoadMessages("en", map[string]string{"hello": "Hello"})
+	_ = b.LoadMessages("de", map[string]string{"hello": "Hallo"})
+
+	cfg := DefaultMiddlewareConfig()
+	cfg.Bundle = b
+	handler := Middleware(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(FromContext(r.Context()).T("hello")))
+	}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Language", "de")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if got := w.Body.String(); got != "Hallo" {
+		t.Errorf("expected 'Hallo', got '%s'", got)
 	}
 }
 
-func TestLogger_NotCalledOnSuccess(t *testing.T) {
-	logger := &testLogger{}
-	b := New(&Config{Logger: logger})
+func TestMiddleware_BundleGlobals(t *testing.T) {
+	Init(&Config{DefaultLang: "en"})
+	_ = LoadMessages("de", map[string]string{"hello": "Hallo (global)"})
+	b := New(&Config{DefaultLang: "en"})
 	_ = b.LoadMessages("en", map[string]string{"hello": "Hello"})
+	_ = b.LoadMessages("de", map[string]string{"hello": "Hallo"})
+	_ = b.LoadContent("de", "json", []byte(`{"hello_n": {"one": "Hallo", "other": "Hallo {{.Count}}"}}`))
 
-	b.T("hello")
+	cfg := DefaultMiddlewareConfig()
+	cfg.Bundle = b
+	handler := Middleware(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		msg, _ := Lookup("hello", WithContext(ctx))
+		w.Write([]byte(strings.Join([]string{
+			T("hello", WithContext(ctx)),
+			msg,
+			Resolve("hello", WithContext(ctx)).Text,
+			TC(ctx, "hello"),
+			TCf(ctx, "hello"),
+			TCp(ctx, "hello_n", 1),
+		}, ",")))
+	}))
 
-	if len(logger.warnings) != 0 {
-		t.Errorf("expected 0 warnings on success, got %d", len(logger.warnings))
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Language", "de")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if got := w.Body.String(); got != "Hallo,Hallo,Hallo,Hallo,Hallo,Hallo" {
+		t.Errorf("expected context bundle translations, got '%s'", got)
+	}
+	if !Has("hello", WithContext(ContextWithBundle(context.Background(), b)), WithLang("en")) {
+		t.Error("Has should use the context bundle")
 	}
 }
 
-// ========== 加载测试 ==========
+// ========== Transport 测试 ==========
 
-func TestLoadMessages(t *testing.T) {
-	b := New(nil)
-	err := b.LoadMessages("en", map[string]string{
-		"hello": "Hello",
-		"world": "World",
+// roundTripFunc 将函数适配为 http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestTransport(t *testing.T) {
+	var seen http.Header
+	base := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		seen = r.Header
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: r}, nil
 	})
-	if err != nil {
-		t.Fatalf("LoadMessages failed: %v", err)
-	}
+	client := &http.Client{Transport: &Transport{Base: base, Header: "X-App-Locale"}}
 
-	if got := b.T("hello"); got != "Hello" {
-		t.Errorf("expected 'Hello', got '%s'", got)
+	ctx := ContextWithLang(context.Background(), "zh-CN")
+	req, _ := http.NewRequestWithContext(ctx, "GET", "http://svc/", nil)
+	if _, err := client.Do(req); err != nil {
+		t.Fatal(err)
 	}
-	if got := b.T("world"); got != "World" {
-		t.Errorf("expected 'World', got '%s'", got)
+	if seen.Get("Accept-Language") != "zh-CN" || seen.Get("X-App-Locale") != "zh-CN" {
+		t.Errorf("unexpected headers: %v", seen)
+	}
+	if req.Header.Get("Accept-Language") != "" {
+		t.Error("original request should not be modified")
 	}
-}
 
-func TestLoadContent_JSON(t *testing.T) {
-	b := New(nil)
-	data := []byte(`{"hello": "你好", "world": "世界"}`)
-	err := b.LoadContent("zh-CN", "json", data)
-	if err != nil {
-		t.Fatalf("LoadContent failed: %v", err)
+	// 已设置的请求头不覆盖
+	req, _ = http.NewRequestWithContext(ctx, "GET", "http://svc/", nil)
+	req.Header.Set("Accept-Language", "ja")
+	_, _ = client.Do(req)
+	if seen.Get("Accept-Language") != "ja" {
+		t.Errorf("expected 'ja', got '%s'", seen.Get("Accept-Language"))
 	}
 
-	if got := b.T("hello", WithLang("zh-CN")); got != "你好" {
-		t.Errorf("expected '你好', got '%s'", got)
+	// context 中没有语言时不设置
+	req, _ = http.NewRequest("GET", "http://svc/", nil)
+	_, _ = client.Do(req)
+	if seen.Get("Accept-Language") != "" {
+		t.Errorf("expected no Accept-Language, got '%s'", seen.Get("Accept-Language"))
 	}
 }
 
-func TestLoadContent_Nested(t *testing.T) {
-	b := New(nil)
-	data := []byte(`{
-		"common": {
-			"confirm": "确定",
-			"cancel": "取消"
-		},
-		"user": {
-			"profile": {
-				"title": "个人资料"
-			}
-		}
-	}`)
-	err := b.LoadContent("zh-CN", "json", data)
+func TestTransport_EndToEnd(t *testing.T) {
+	Init(&Config{DefaultLang: "en"})
+	_ = LoadMessages("en", map[string]string{"hello": "Hello"})
+	_ = LoadMessages("ja", map[string]string{"hello": "こんにちは"})
+
+	downstream := httptest.NewServer(Middleware(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(T("hello", WithContext(r.Context()))))
+	})))
+	defer downstream.Close()
+
+	client := &http.Client{Transport: NewTransport(nil)}
+	req, _ := http.NewRequestWithContext(ContextWithLang(context.Background(), "ja"), "GET", downstream.URL, nil)
+	resp, err := client.Do(req)
 	if err != nil {
-		t.Fatalf("LoadContent failed: %v", err)
+		t.Fatal(err)
 	}
+	defer resp.Body.Close()
+	body := new(strings.Builder)
+	_, _ = io.Copy(body, resp.Body)
+	if body.String() != "こんにちは" {
+		t.Errorf("expected 'こんにちは', got '%s'", body.String())
+	}
+}
 
-	tests := map[string]string{
-		"common.confirm":     "确定",
-		"common.cancel":      "取消",
-		"user.profile.title": "个人资料",
+// ========== Carrier 传递测试 ==========
+
+func TestPropagation_Map(t *testing.T) {
+	carrier := MapCarrier{}
+	Inject(ContextWithLang(context.Background(), "zh_CN"), carrier)
+	if carrier[PropagationKey] != "zh-CN" {
+		t.Fatalf("unexpected carrier: %v", carrier)
 	}
 
-	for key, expected := range tests {
-		if got := b.T(key, WithLang("zh-CN")); got != expected {
-			t.Errorf("T(%q) = %q, want %q", key, got, expected)
-		}
+	ctx := Extract(context.Background(), carrier)
+	if lang, _ := ctx.Value(langCtxKey).(string); lang != "zh-CN" {
+		t.Errorf("expected 'zh-CN', got '%s'", lang)
 	}
 }
 
-func TestLoadContent_YAML(t *testing.T) {
-	b := New(nil)
-	data := []byte("hello: 你好\nworld: 世界\n")
-	err := b.LoadContent("zh-CN", "yaml", data)
-	if err != nil {
-		t.Fatalf("LoadContent YAML failed: %v", err)
+func TestPropagation_Header(t *testing.T) {
+	h := http.Header{}
+	Inject(ContextWithLang(context.Background(), "ja"), HeaderCarrier(h))
+	if h.Get("Accept-Language") != "ja" {
+		t.Fatalf("unexpected header: %v", h)
 	}
 
-	if got := b.T("hello", WithLang("zh-CN")); got != "你好" {
-		t.Errorf("expected '你好', got '%s'", got)
+	h.Set("Accept-Language", "de;q=0.5, fr")
+	ctx := Extract(context.Background(), HeaderCarrier(h))
+	if lang, _ := ctx.Value(langCtxKey).(string); lang != "fr" {
+		t.Errorf("expected 'fr', got '%s'", lang)
 	}
 }
 
-func TestLoadContent_Plural(t *testing.T) {
-	b := New(nil)
-	data := []byte(`{
-		"items": {
-			"one": "{{.Count}} item",
-			"other": "{{.Count}} items"
-		}
-	}`)
-	err := b.LoadContent("en", "json", data)
-	if err != nil {
-		t.Fatalf("LoadContent failed: %v", err)
+func TestPropagation_Empty(t *testing.T) {
+	carrier := MapCarrier{}
+	Inject(context.Background(), carrier)
+	if len(carrier) != 0 {
+		t.Errorf("expected empty carrier, got %v", carrier)
 	}
 
-	if got := b.T("items", WithCount(1)); got != "1 item" {
-		t.Errorf("expected '1 item', got '%s'", got)
-	}
-	if got := b.T("items", WithCount(3)); got != "3 items" {
-		t.Errorf("expected '3 items', got '%s'", got)
+	ctx := context.Background()
+	if Extract(ctx, carrier) != ctx {
+		t.Error("expected original context")
 	}
 }
 
-// ========== Context 测试 ==========
+// ========== 路径前缀测试 ==========
 
-func TestContextWithLang(t *testing.T) {
-	ctx := ContextWithLang(context.Background(), "zh-CN")
-	lang := LangFromContext(ctx)
-	if lang != "zh-CN" {
-		t.Errorf("expected 'zh-CN', got '%s'", lang)
-	}
-}
+func TestLocalizePath(t *testing.T) {
+	b := New(nil)
+	_ = b.LoadMessages("en", map[string]string{"hello": "Hello"})
+	_ = b.LoadMessages("zh-CN", map[string]string{"hello": "你好"})
 
-func TestContextWithLang_Normalize(t *testing.T) {
-	ctx := ContextWithLang(context.Background(), "zh_TW")
-	lang := LangFromContext(ctx)
-	if lang != "zh-TW" {
-		t.Errorf("expected 'zh-TW', got '%s'", lang)
+	tests := []struct {
+		path, lang, expected string
+	}{
+		{"/products", "zh-CN", "/zh-CN/products"},
+		{"/en/products", "zh_CN", "/zh-CN/products"},
+		{"/", "en", "/en/"},
+		{"products", "en", "/en/products"},
+	}
+	for _, tt := range tests {
+		if got := b.LocalizePath(tt.path, tt.lang); got != tt.expected {
+			t.Errorf("LocalizePath(%q, %q) = %q, want %q", tt.path, tt.lang, got, tt.expected)
+		}
 	}
-}
 
-func TestLangFromContext_NoLang(t *testing.T) {
-	// 确保全局实例已初始化
-	Init(&Config{DefaultLang: "en"})
-	lang := LangFromContext(context.Background())
-	if lang != "en" {
-		t.Errorf("expected 'en', got '%s'", lang)
+	alt := b.AlternatePaths("/zh-CN/products")
+	if alt["en"] != "/en/products" || alt["zh-CN"] != "/zh-CN/products" || len(alt) != 2 {
+		t.Errorf("unexpected alternates: %v", alt)
 	}
 }
 
-// ========== Middleware 测试 ==========
+// ========== 语言切换测试 ==========
 
-func TestMiddleware_QueryParam(t *testing.T) {
+func TestSwitchHandler(t *testing.T) {
 	Init(&Config{DefaultLang: "en"})
 	_ = LoadMessages("en", map[string]string{"hello": "Hello"})
-	_ = LoadMessages("zh-CN", map[string]string{"hello": "你好"})
-
-	handler := Middleware(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		msg := T("hello", WithContext(r.Context()))
-		w.Write([]byte(msg))
-	}))
-
-	req := httptest.NewRequest("GET", "/?lang=zh-CN", nil)
-	w := httptest.NewRecorder()
-	handler.ServeHTTP(w, req)
+	_ = LoadMessages("ja", map[string]string{"hello": "こんにちは"})
 
-	if got := w.Body.String(); got != "你好" {
-		t.Errorf("expected '你好', got '%s'", got)
+	handler := SwitchHandler(nil)
+	tests := []struct {
+		url      string
+		status   int
+		location string
+	}{
+		{"/lang?lang=ja&return=/products?page=2", http.StatusSeeOther, "/products?page=2"},
+		{"/lang?lang=ja", http.StatusSeeOther, "/"},
+		{"/lang?lang=ja&return=//evil.com/x", http.StatusSeeOther, "/"},
+		{"/lang?lang=ja&return=/%5Cevil.com", http.StatusSeeOther, "/"},
+		{"/lang?lang=ja&return=https://evil.com", http.StatusSeeOther, "/"},
+		{"/lang?lang=ja&return=/%09/evil.com", http.StatusSeeOther, "/"},
+		{"/lang?lang=ja&return=/%0A/evil.com", http.StatusSeeOther, "/"},
+		{"/lang?lang=ja&return=/%7F/evil.com", http.StatusSeeOther, "/"},
+		{"/lang?lang=ja&return=/a%5C..%5C%5Cevil.com", http.StatusSeeOther, "/"},
+		{"/lang?lang=ja&return=/products%23top", http.StatusSeeOther, "/products#top"},
+		{"/lang?lang=fr&return=/products", http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", tt.url, nil))
+		if w.Code != tt.status {
+			t.Errorf("%s: expected status %d, got %d", tt.url, tt.status, w.Code)
+			continue
+		}
+		if got := w.Header().Get("Location"); got != tt.location {
+			t.Errorf("%s: expected location '%s', got '%s'", tt.url, tt.location, got)
+		}
+		if tt.status == http.StatusSeeOther {
+			cookies := w.Result().Cookies()
+			if len(cookies) != 1 || cookies[0].Value != "ja" {
+				t.Errorf("%s: expected lang cookie 'ja', got %v", tt.url, cookies)
+			}
+		}
 	}
 }
 
-func TestMiddleware_AcceptLanguage(t *testing.T) {
+func TestSwitchHandler_PathPrefix(t *testing.T) {
 	Init(&Config{DefaultLang: "en"})
 	_ = LoadMessages("en", map[string]string{"hello": "Hello"})
-	_ = LoadMessages("zh-CN", map[string]string{"hello": "你好"})
-
-	handler := Middleware(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		msg := T("hello", WithContext(r.Context()))
-		w.Write([]byte(msg))
-	}))
+	_ = LoadMessages("ja", map[string]string{"hello": "こんにちは"})
 
-	req := httptest.NewRequest("GET", "/", nil)
-	req.Header.Set("Accept-Language", "zh-CN,zh;q=0.9,en;q=0.8")
+	cfg := DefaultMiddlewareConfig()
+	cfg.Sources = []LangSource{SourcePath, SourceCookie}
 	w := httptest.NewRecorder()
-	handler.ServeHTTP(w, req)
+	SwitchHandler(cfg).ServeHTTP(w, httptest.NewRequest("GET", "/lang?lang=ja&return=/en/products", nil))
 
-	if got := w.Body.String(); got != "你好" {
-		t.Errorf("expected '你好', got '%s'", got)
+	if got := w.Header().Get("Location"); got != "/ja/products" {
+		t.Errorf("expected '/ja/products', got '%s'", got)
 	}
 }
 
-func TestMiddleware_Cookie(t *testing.T) {
+func TestRootRedirect(t *testing.T) {
 	Init(&Config{DefaultLang: "en"})
 	_ = LoadMessages("en", map[string]string{"hello": "Hello"})
-	_ = LoadMessages("zh-CN", map[string]string{"hello": "你好"})
+	_ = LoadMessages("ja", map[string]string{"hello": "こんにちは"})
 
-	handler := Middleware(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		msg := T("hello", WithContext(r.Context()))
-		w.Write([]byte(msg))
+	handler := RootRedirect(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("next"))
 	}))
 
-	req := httptest.NewRequest("GET", "/", nil)
-	req.AddCookie(&http.Cookie{Name: "lang", Value: "zh-CN"})
+	req := httptest.NewRequest("GET", "/?ref=ad", nil)
+	req.Header.Set("Accept-Language", "ja,en;q=0.5")
 	w := httptest.NewRecorder()
 	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusFound || w.Header().Get("Location") != "/ja/?ref=ad" {
+		t.Errorf("unexpected redirect: %d %s", w.Code, w.Header().Get("Location"))
+	}
 
-	if got := w.Body.String(); got != "你好" {
-		t.Errorf("expected '你好', got '%s'", got)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/ja/products", nil))
+	if w.Body.String() != "next" {
+		t.Errorf("non-root path should pass through")
 	}
 }
 
-func TestMiddleware_CustomConfig(t *testing.T) {
-	Init(&Config{DefaultLang: "en"})
-	_ = LoadMessages("en", map[string]string{"hello": "Hello"})
-	_ = LoadMessages("zh-CN", map[string]string{"hello": "你好"})
-
-	cfg := &MiddlewareConfig{
-		Sources:    []LangSource{SourceQuery},
-		QueryParam: "locale",
-	}
+// ========== Match 测试 ==========
 
-	handler := Middleware(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		msg := T("hello", WithContext(r.Context()))
-		w.Write([]byte(msg))
-	}))
+func TestMatch(t *testing.T) {
+	b := New(nil)
+	_ = b.LoadMessages("en", map[string]string{"hello": "Hello"})
+	_ = b.LoadMessages("zh-CN", map[string]string{"hello": "你好"})
+	_ = b.LoadMessages("zh-TW", map[string]string{"hello": "你好"})
 
-	req := httptest.NewRequest("GET", "/?locale=zh-CN", nil)
-	w := httptest.NewRecorder()
-	handler.ServeHTTP(w, req)
+	tests := []struct {
+		prefs    []string
+		expected string
+	}{
+		{[]string{"zh-Hans-CN;q=0.9, en;q=0.5"}, "zh-CN"},
+		{[]string{"zh_TW"}, "zh-TW"},
+		{[]string{"zh-Hant-HK"}, "zh-TW"},
+		{[]string{"en-GB"}, "en"},
+		{[]string{"fr", "zh-CN"}, "zh-CN"},
+		{[]string{"fr"}, ""},
+		{[]string{""}, ""},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := b.Match(tt.prefs...); got != tt.expected {
+			t.Errorf("Match(%q) = %q, want %q", tt.prefs, got, tt.expected)
+		}
+	}
+}
 
-	if got := w.Body.String(); got != "你好" {
-		t.Errorf("expected '你好', got '%s'", got)
+func TestMatch_NoLanguages(t *testing.T) {
+	b := New(nil)
+	if got := b.Match("en"); got != "" {
+		t.Errorf("expected empty match, got '%s'", got)
 	}
 }
 
@@ -697,6 +2491,8 @@ func TestParseAcceptLanguage(t *testing.T) {
 		{"", ""},
 		{"ja", "ja"},
 		{"fr;q=0.9", "fr"},
+		{"*;q=0.1, fr;q=0.9, de;q=1.0", "de"},
+		{"*", ""},
 	}
 
 	for _, tt := range tests {
@@ -705,3 +2501,25 @@ func TestParseAcceptLanguage(t *testing.T) {
 		}
 	}
 }
+
+func TestParseAcceptLanguagePublic(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"*;q=0.1, fr;q=0.9, de;q=1.0", "de,fr,*"},
+		{"zh-CN,zh;q=0.9,en;q=0.8", "zh-CN,zh,en"},
+		{"en;q=0.5, ja, ko;q=0.5", "ja,en,ko"},
+		{"fr;q=0, en", "en"},
+		{"en;q=abc, de;q=2, ja", "ja"},
+		{"!!invalid!!, zh_TW;q=0.7", "zh-TW"},
+		{"en ; Q=0.3 , fr", "fr,en"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := strings.Join(ParseAcceptLanguage(tt.input), ","); got != tt.expected {
+			t.Errorf("ParseAcceptLanguage(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
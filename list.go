@@ -0,0 +1,203 @@
+package gi18n
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// ListStyle 列表的连接方式
+type ListStyle int
+
+const (
+	ListAnd  ListStyle = iota // 并列: A, B, and C
+	ListOr                    // 选择: A, B, or C
+	ListUnit                  // 单位: 5 ft, 2 in
+)
+
+// listStyleNames 风格名称，用于语言包中的覆盖 key 和模板函数参数
+var listStyleNames = [...]string{"and", "or", "unit"}
+
+// listSeparators 列表分隔符
+type listSeparators struct {
+	middle string // 中间元素之间
+	two    string // 只有两个元素时
+	end    string // 最后两个元素之间
+}
+
+// listLocales 内置的列表分隔符（参考 CLDR），按 ListAnd、ListOr、ListUnit 排列
+var listLocales = map[string][3]listSeparators{
+	"en": {
+		{", ", " and ", ", and "},
+		{", ", " or ", ", or "},
+		{", ", ", ", ", "},
+	},
+	"zh": {
+		{"、", "和", "和"},
+		{"、", "或", "或"},
+		{"", "", ""},
+	},
+	"zh-Hant": {
+		{"、", "和", "和"},
+		{"、", "或", "或"},
+		{" ", " ", " "},
+	},
+	"ja": {
+		{"、", "、", "、"},
+		{"、", "または", "、または"},
+		{" ", " ", " "},
+	},
+	"ko": {
+		{", ", " 및 ", " 및 "},
+		{", ", " 또는 ", " 또는 "},
+		{" ", " ", " "},
+	},
+	"de": {
+		{", ", " und ", " und "},
+		{", ", " oder ", " oder "},
+		{", ", ", ", " und "},
+	},
+	"fr": {
+		{", ", " et ", " et "},
+		{", ", " ou ", " ou "},
+		{", ", " et ", " et "},
+	},
+	"es": {
+		{", ", " y ", " y "},
+		{", ", " o ", " o "},
+		{", ", " y ", " y "},
+	},
+	"it": {
+		{", ", " e ", " e "},
+		{", ", " o ", " o "},
+		{", ", ", ", ", "},
+	},
+	"pt": {
+		{", ", " e ", " e "},
+		{", ", " ou ", " ou "},
+		{", ", " e ", " e "},
+	},
+	"ru": {
+		{", ", " и ", " и "},
+		{", ", " или ", " или "},
+		{" ", " ", " "},
+	},
+}
+
+// listSeparatorsFor 返回语言和风格对应的分隔符
+// 语言包中的 gi18n.list.<style>.middle / two / end 优先于内置数据
+func (b *Bundle) listSeparatorsFor(lang string, style ListStyle) listSeparators {
+	if style < ListAnd || style > ListUnit {
+		style = ListAnd
+	}
+	seps := builtinListSeparators(parseLanguageTag(lang), style)
+
+	prefix := "gi18n.list." + listStyleNames[style] + "."
+	if v, ok := b.catalogOverride(lang, prefix+"middle"); ok {
+		seps.middle = v
+	}
+	if v, ok := b.catalogOverride(lang, prefix+"two"); ok {
+		seps.two = v
+	}
+	if v, ok := b.catalogOverride(lang, prefix+"end"); ok {
+		seps.end = v
+	}
+	return seps
+}
+
+// builtinListSeparators 内置分隔符，没有内置数据的语言使用英语
+func builtinListSeparators(tag language.Tag, style ListStyle) listSeparators {
+	styles, ok := lookupLocaleData(listLocales, tag)
+	if !ok {
+		styles = listLocales["en"]
+	}
+	return styles[style]
+}
+
+// joinList 按分隔符连接列表
+func joinList(items []string, seps listSeparators) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	case 2:
+		return items[0] + seps.two + items[1]
+	}
+
+	var sb strings.Builder
+	last := len(items) - 1
+	for i, item := range items[:last] {
+		if i > 0 {
+			sb.WriteString(seps.middle)
+		}
+		sb.WriteString(item)
+	}
+	sb.WriteString(seps.end)
+	sb.WriteString(items[last])
+	return sb.String()
+}
+
+// parseListStyle 解析模板函数中的风格名称，未知名称使用 ListAnd
+func parseListStyle(args []string) ListStyle {
+	if len(args) > 0 {
+		for i, name := range listStyleNames {
+			if strings.EqualFold(args[0], name) {
+				return ListStyle(i)
+			}
+		}
+	}
+	return ListAnd
+}
+
+// FormatList 按语言连接列表，style 默认为 ListAnd
+//
+//	bundle.FormatList("en", []string{"Alice", "Bob", "Carol"})      // "Alice, Bob, and Carol"
+//	bundle.FormatList("zh-CN", []string{"Alice", "Bob", "Carol"})   // "Alice、Bob和Carol"
+//	bundle.FormatList("de", []string{"Alice", "Bob"}, gi18n.ListOr) // "Alice oder Bob"
+//
+// 分隔符可在语言包中通过 gi18n.list.<style>.middle / two / end 覆盖，style 为 and、or、unit:
+//
+//	{"gi18n.list.and.end": " and "}
+func (b *Bundle) FormatList(lang string, items []string, style ...ListStyle) string {
+	s := ListAnd
+	if len(style) > 0 {
+		s = style[0]
+	}
+	return joinList(items, b.listSeparatorsFor(lang, s))
+}
+
+// FormatList 按绑定的语言连接列表
+func (t *Translator) FormatList(items []string, style ...ListStyle) string {
+	return t.bundle.FormatList(t.lang, items, style...)
+}
+
+// listFunc 消息模板中的 list 函数，参数为 []string 或其它切片（元素按 fmt.Sprint 转换）
+//
+//	{{list .Names}}       // Alice, Bob, and Carol
+//	{{list .Names "or"}}  // Alice, Bob, or Carol
+func (b *Bundle) listFunc(lang string) func(v interface{}, style ...string) (string, error) {
+	return func(v interface{}, style ...string) (string, error) {
+		var items []string
+		switch x := v.(type) {
+		case []string:
+			items = x
+		case []interface{}:
+			items = make([]string, len(x))
+			for i, item := range x {
+				items[i] = fmt.Sprint(item)
+			}
+		default:
+			return "", fmt.Errorf("gi18n: list: unsupported type %T", v)
+		}
+		return b.FormatList(lang, items, parseListStyle(style)), nil
+	}
+}
+
+// ========== 全局函数 ==========
+
+// FormatList 按语言连接列表（全局）
+func FormatList(lang string, items []string, style ...ListStyle) string {
+	return Default().FormatList(lang, items, style...)
+}
@@ -14,62 +14,68 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-// registerUnmarshalers 注册各格式的解析器
-func (b *Bundle) registerUnmarshalers() {
-	b.bundle.RegisterUnmarshalFunc("json", json.Unmarshal)
-	b.bundle.RegisterUnmarshalFunc("yaml", yaml.Unmarshal)
-	b.bundle.RegisterUnmarshalFunc("yml", yaml.Unmarshal)
-	b.bundle.RegisterUnmarshalFunc("toml", toml.Unmarshal)
-}
-
 // Load 从目录加载语言文件
 // 支持 .json, .yaml, .yml, .toml 格式
 // 文件名格式: {语言标记}.{扩展名}，如 en.json, zh-CN.yaml
+//
+// 所有加载方法都会在旁路构建完整的新翻译目录，校验通过后一次性替换，
+// 加载失败时当前翻译保持不变。此前加载的来源复用已解析的结果，不会重新读取
 func (b *Bundle) Load(dir string) error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
+	src := loadSource{
+		dir: dir,
+		read: func(b *Bundle) ([]messageSet, error) {
+			return b.readDir(dir)
+		},
+	}
+	return b.apply(src)
+}
 
+// readDir 读取目录下的全部语言文件
+func (b *Bundle) readDir(dir string) ([]messageSet, error) {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
-		return fmt.Errorf("gi18n: failed to read directory %s: %w", dir, err)
+		return nil, fmt.Errorf("gi18n: failed to read directory %s: %w", dir, err)
 	}
 
+	var sets []messageSet
 	for _, entry := range entries {
 		if entry.IsDir() {
 			continue
 		}
 
-		if err := b.loadFile(dir, entry.Name()); err != nil {
-			return err
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if !isSupportedExt(ext) {
+			continue
 		}
-	}
 
-	b.clearLocalizerCache()
-	return nil
-}
-
-// loadFile 加载单个文件
-func (b *Bundle) loadFile(dir, filename string) error {
-	ext := strings.ToLower(filepath.Ext(filename))
-	if !isSupportedExt(ext) {
-		return nil
-	}
+		filePath := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("gi18n: failed to read file %s: %w", filePath, err)
+		}
 
-	filePath := filepath.Join(dir, filename)
-	data, err := os.ReadFile(filePath)
-	if err != nil {
-		return fmt.Errorf("gi18n: failed to read file %s: %w", filePath, err)
+		set, err := b.parseData(extractLangFromFilename(entry.Name()), ext, data)
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, set)
 	}
-
-	lang := extractLangFromFilename(filename)
-	return b.loadData(lang, ext, data)
+	return sets, nil
 }
 
 // LoadFS 从 embed.FS 加载语言文件
 func (b *Bundle) LoadFS(fsys embed.FS, root string) error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
+	src := loadSource{
+		read: func(b *Bundle) ([]messageSet, error) {
+			return b.readFS(fsys, root)
+		},
+	}
+	return b.apply(src)
+}
 
+// readFS 读取 embed.FS 下的全部语言文件
+func (b *Bundle) readFS(fsys embed.FS, root string) ([]messageSet, error) {
+	var sets []messageSet
 	err := fs.WalkDir(fsys, root, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
@@ -88,70 +94,86 @@ func (b *Bundle) LoadFS(fsys embed.FS, root string) error {
 			return fmt.Errorf("gi18n: failed to read file %s: %w", path, err)
 		}
 
-		lang := extractLangFromFilename(d.Name())
-		return b.loadData(lang, ext, data)
+		set, err := b.parseData(extractLangFromFilename(d.Name()), ext, data)
+		if err != nil {
+			return err
+		}
+		sets = append(sets, set)
+		return nil
 	})
-
-	if err != nil {
-		return err
-	}
-
-	b.clearLocalizerCache()
-	return nil
+	return sets, err
 }
 
 // LoadContent 从字节内容加载语言包
 // lang: 语言标记，如 "en", "zh-CN"
 // format: 格式，如 "json", "yaml", "toml"
-// data: 文件内容
+// data: 文件内容，加载时复制，之后修改不影响已加载的翻译
 func (b *Bundle) LoadContent(lang, format string, data []byte) error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-
 	ext := "." + strings.TrimPrefix(format, ".")
-	if err := b.loadData(lang, ext, data); err != nil {
-		return err
+	data = append([]byte(nil), data...)
+	src := loadSource{
+		read: func(b *Bundle) ([]messageSet, error) {
+			set, err := b.parseData(lang, ext, data)
+			if err != nil {
+				return nil, err
+			}
+			return []messageSet{set}, nil
+		},
 	}
-
-	b.clearLocalizerCache()
-	return nil
+	return b.apply(src)
 }
 
 // LoadMessages 直接加载消息映射（简化格式）
+// messages 在加载时复制，之后修改不影响已加载的翻译
 func (b *Bundle) LoadMessages(lang string, messages map[string]string) error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
+	set := messageSetFromMap(lang, messages)
+	src := loadSource{
+		read: func(b *Bundle) ([]messageSet, error) {
+			return []messageSet{set}, nil
+		},
+	}
+	return b.apply(src)
+}
 
-	tag := parseLanguageTag(lang)
+// messageSetFromMap 将消息映射转换为消息集合
+func messageSetFromMap(lang string, messages map[string]string) messageSet {
+	set := messageSet{
+		lang:     normalizeLanguageTag(lang),
+		tag:      parseLanguageTag(lang),
+		messages: make([]*i18n.Message, 0, len(messages)),
+	}
 	for id, text := range messages {
-		if err := b.bundle.AddMessages(tag, &i18n.Message{
+		set.messages = append(set.messages, &i18n.Message{
 			ID:    id,
 			Other: text,
-		}); err != nil {
-			return fmt.Errorf("gi18n: failed to add message %s: %w", id, err)
-		}
+		})
 	}
-
-	b.addSupported(lang)
-	b.clearLocalizerCache()
-	return nil
+	return set
 }
 
-// loadData 加载数据到 bundle
-func (b *Bundle) loadData(lang, ext string, data []byte) error {
+// parseData 解析语言文件内容
+func (b *Bundle) parseData(lang, ext string, data []byte) (messageSet, error) {
+	if !isSupportedExt(strings.ToLower(ext)) {
+		return messageSet{}, fmt.Errorf("%w: %s", ErrInvalidFormat, strings.TrimPrefix(ext, "."))
+	}
+
 	// 先尝试解析为通用格式，处理嵌套和简化写法
 	processed, err := b.preprocessData(data, ext)
 	if err != nil {
-		return err
+		return messageSet{}, err
 	}
 
 	filename := fmt.Sprintf("%s%s", normalizeLanguageTag(lang), ext)
-	if _, err := b.bundle.ParseMessageFileBytes(processed, filename); err != nil {
-		return fmt.Errorf("gi18n: failed to parse message file %s: %w", filename, err)
+	file, err := i18n.ParseMessageFileBytes(processed, filename, unmarshalFuncs)
+	if err != nil {
+		return messageSet{}, fmt.Errorf("gi18n: failed to parse message file %s: %w", filename, err)
 	}
 
-	b.addSupported(lang)
-	return nil
+	return messageSet{
+		lang:     normalizeLanguageTag(lang),
+		tag:      file.Tag,
+		messages: file.Messages,
+	}, nil
 }
 
 // preprocessData 预处理数据，处理嵌套和简化写法
@@ -283,3 +305,13 @@ func LoadContent(lang, format string, data []byte) error {
 func LoadMessages(lang string, messages map[string]string) error {
 	return Default().LoadMessages(lang, messages)
 }
+
+// Reload 重新加载全部来源（全局）
+func Reload() error {
+	return Default().Reload()
+}
+
+// Rollback 回滚到上一份翻译快照（全局）
+func Rollback() error {
+	return Default().Rollback()
+}
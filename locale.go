@@ -0,0 +1,173 @@
+package gi18n
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+)
+
+type localeCtxKey struct{}
+
+// Locale 完整的区域设置：语言、地区、时区和货币
+type Locale struct {
+	Lang     string         // 语言标签，如 "zh-CN"
+	Region   string         // 地区代码，如 "CN"，用于数字格式
+	Location *time.Location // 时区
+	Currency currency.Unit  // 货币
+}
+
+// NewLocale 根据语言创建 Locale，地区和货币由语言推导，时区为 time.Local
+//
+//	gi18n.NewLocale("de") // {Lang: "de", Region: "DE", Location: Local, Currency: EUR}
+func NewLocale(lang string) Locale {
+	return Locale{Lang: normalizeLanguageTag(lang)}.withDefaults()
+}
+
+// Tag 返回 Locale 对应的语言标签，包含地区信息
+func (l Locale) Tag() language.Tag {
+	tag := parseLanguageTag(l.Lang)
+	if l.Region == "" {
+		return tag
+	}
+	region, err := language.ParseRegion(l.Region)
+	if err != nil {
+		return tag
+	}
+	if r, conf := tag.Region(); conf == language.Exact && r == region {
+		return tag
+	}
+	if t, err := language.Compose(tag, region); err == nil {
+		return t
+	}
+	return tag
+}
+
+// lookupLocaleData 按语言标签查找内置的本地化数据
+// 依次匹配完整标签、语言-文字、语言-地区、基础语言
+func lookupLocaleData[T any](m map[string]T, tag language.Tag) (T, bool) {
+	if v, ok := m[tag.String()]; ok {
+		return v, true
+	}
+	base, _ := tag.Base()
+	if script, conf := tag.Script(); conf != language.No {
+		if v, ok := m[base.String()+"-"+script.String()]; ok {
+			return v, true
+		}
+	}
+	if region, conf := tag.Region(); conf == language.Exact {
+		if v, ok := m[base.String()+"-"+region.String()]; ok {
+			return v, true
+		}
+	}
+	v, ok := m[base.String()]
+	return v, ok
+}
+
+// withDefaults 补全未设置的字段
+func (l Locale) withDefaults() Locale {
+	if l.Region == "" {
+		if region, conf := parseLanguageTag(l.Lang).Region(); conf != language.No {
+			l.Region = region.String()
+		}
+	}
+	if l.Location == nil {
+		l.Location = time.Local
+	}
+	if l.Currency == (currency.Unit{}) {
+		if region, err := language.ParseRegion(l.Region); err == nil {
+			if unit, ok := currency.FromRegion(region); ok {
+				l.Currency = unit
+			}
+		}
+	}
+	return l
+}
+
+// localeForLang 将 Locale 绑定到目标语言
+// Locale 已绑定其它语言时只保留时区，地区和货币按目标语言重新推导
+func localeForLang(l Locale, lang string) Locale {
+	if l.Lang != "" && canonicalLang(l.Lang) != canonicalLang(lang) {
+		l = Locale{Location: l.Location}
+	}
+	l.Lang = normalizeLanguageTag(lang)
+	return l.withDefaults()
+}
+
+// ContextWithLocale 将 Locale 注入到 context 中，同时设置语言（等同 ContextWithLang）
+//
+//	ctx := gi18n.ContextWithLocale(ctx, gi18n.Locale{Lang: "zh-CN", Location: shanghai})
+func ContextWithLocale(ctx context.Context, l Locale) context.Context {
+	l.Lang = normalizeLanguageTag(l.Lang)
+	ctx = context.WithValue(ctx, localeCtxKey{}, l)
+	if l.Lang != "" {
+		ctx = ContextWithLang(ctx, l.Lang)
+	}
+	return ctx
+}
+
+// LocaleFromContext 从 context 获取 Locale
+// 未设置的字段由 context 中的语言推导；没有 Locale 或语言与 Locale 不一致时由语言推导
+func LocaleFromContext(ctx context.Context) Locale {
+	l, _ := ctx.Value(localeCtxKey{}).(Locale)
+	lang := l.Lang
+	if v, ok := ctx.Value(langCtxKey).(string); ok && v != "" {
+		lang = v
+	}
+	if lang == "" {
+		lang = LangFromContext(ctx)
+	}
+	return localeForLang(l, lang)
+}
+
+// detectLocale 按中间件配置从请求头/Cookie 读取地区、时区和货币
+func detectLocale(r *http.Request, cfg *MiddlewareConfig, lang string) Locale {
+	l := Locale{Lang: lang}
+
+	if v := requestValue(r, cfg.RegionHeader, cfg.RegionCookie); v != "" {
+		if region, err := language.ParseRegion(v); err == nil {
+			l.Region = region.String()
+		}
+	}
+	if v := requestValue(r, cfg.TimezoneHeader, cfg.TimezoneCookie); v != "" {
+		if loc, err := time.LoadLocation(v); err == nil {
+			l.Location = loc
+		}
+	}
+	if v := requestValue(r, cfg.CurrencyHeader, cfg.CurrencyCookie); v != "" {
+		if unit, err := currency.ParseISO(strings.ToUpper(v)); err == nil {
+			l.Currency = unit
+		}
+	}
+	return l.withDefaults()
+}
+
+// addLocaleVary 按配置的地区、时区、货币来源添加 Vary 字段
+func addLocaleVary(h http.Header, cfg *MiddlewareConfig) {
+	for _, header := range []string{cfg.RegionHeader, cfg.TimezoneHeader, cfg.CurrencyHeader} {
+		if header != "" {
+			addVary(h, http.CanonicalHeaderKey(header))
+		}
+	}
+	if cfg.RegionCookie != "" || cfg.TimezoneCookie != "" || cfg.CurrencyCookie != "" {
+		addVary(h, "Cookie")
+	}
+}
+
+// requestValue 依次从请求头和 Cookie 读取值
+func requestValue(r *http.Request, header, cookie string) string {
+	if header != "" {
+		if v := strings.TrimSpace(r.Header.Get(header)); v != "" {
+			return v
+		}
+	}
+	if cookie != "" {
+		if c, err := r.Cookie(cookie); err == nil {
+			return strings.TrimSpace(c.Value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,54 @@
+package gi18n
+
+import (
+	"golang.org/x/text/language"
+)
+
+// Match 将偏好语言与已加载的语言协商，返回最匹配的已加载语言
+// 每个参数可以是单个语言标签或完整的 Accept-Language 值（通过 ParseAcceptLanguage 解析）
+// 脚本和地区变体按 CLDR 规则匹配，没有可接受的匹配时返回空字符串
+//
+//	bundle.Match("zh-Hans-CN;q=0.9, en;q=0.5") // "zh-CN"
+//	bundle.Match("zh_TW", "en")                // "zh-TW"
+func (b *Bundle) Match(prefs ...string) string {
+	c := b.cat.Load()
+	if len(c.supported) == 0 {
+		return ""
+	}
+
+	var desired []language.Tag
+	for _, pref := range prefs {
+		for _, lang := range ParseAcceptLanguage(pref) {
+			if lang == "*" {
+				continue
+			}
+			desired = append(desired, parseLanguageTag(lang))
+		}
+	}
+	if len(desired) == 0 {
+		return ""
+	}
+
+	_, idx, conf := c.getMatcher().Match(desired...)
+	if conf == language.No {
+		return ""
+	}
+	return c.supported[idx]
+}
+
+// getMatcher 获取已加载语言的 Matcher（每个翻译目录构建一次）
+func (c *catalog) getMatcher() language.Matcher {
+	c.matcherOnce.Do(func() {
+		tags := make([]language.Tag, len(c.supported))
+		for i, l := range c.supported {
+			tags[i] = parseLanguageTag(l)
+		}
+		c.matcher = language.NewMatcher(tags)
+	})
+	return c.matcher
+}
+
+// ========== 全局函数 ==========
+
+// Match 将偏好语言与已加载的语言协商（全局）
+func Match(prefs ...string) string { return Default().Match(prefs...) }
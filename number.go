@@ -0,0 +1,120 @@
+package gi18n
+
+import (
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// NumberOption 数字格式化选项
+type NumberOption func(*numberConfig)
+
+// numberConfig 数字格式化内部配置
+type numberConfig struct {
+	minFraction *int
+	maxFraction *int
+}
+
+// MinFraction 设置最少小数位数，不足时补零
+//
+//	bundle.FormatNumber("en", 2, gi18n.MinFraction(2)) // "2.00"
+func MinFraction(n int) NumberOption {
+	return func(c *numberConfig) {
+		c.minFraction = &n
+	}
+}
+
+// MaxFraction 设置最多小数位数，超出时四舍五入
+//
+//	bundle.FormatNumber("en", 3.14159, gi18n.MaxFraction(2)) // "3.14"
+func MaxFraction(n int) NumberOption {
+	return func(c *numberConfig) {
+		c.maxFraction = &n
+	}
+}
+
+// Fraction 固定小数位数，等同 MinFraction(n) + MaxFraction(n)
+func Fraction(n int) NumberOption {
+	return func(c *numberConfig) {
+		c.minFraction = &n
+		c.maxFraction = &n
+	}
+}
+
+// numberOptions 将 NumberOption 转换为 x/text/number 的选项
+func numberOptions(opts []NumberOption) []number.Option {
+	c := &numberConfig{}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	var result []number.Option
+	if c.minFraction != nil {
+		result = append(result, number.MinFractionDigits(*c.minFraction))
+	}
+	if c.maxFraction != nil {
+		result = append(result, number.MaxFractionDigits(*c.maxFraction))
+	}
+	return result
+}
+
+// formatNumber 按语言格式化数字（整数或小数）
+func formatNumber(tag language.Tag, v interface{}, opts ...NumberOption) string {
+	return message.NewPrinter(tag).Sprint(number.Decimal(v, numberOptions(opts)...))
+}
+
+// formatPercent 按语言格式化百分比，0.256 -> 26%
+func formatPercent(tag language.Tag, v interface{}, opts ...NumberOption) string {
+	return message.NewPrinter(tag).Sprint(number.Percent(v, numberOptions(opts)...))
+}
+
+// FormatNumber 按语言格式化数字（整数或小数）
+//
+//	bundle.FormatNumber("en", 1234567.5) // "1,234,567.5"
+//	bundle.FormatNumber("de", 1234567.5) // "1.234.567,5"
+func (b *Bundle) FormatNumber(lang string, v interface{}, opts ...NumberOption) string {
+	return formatNumber(parseLanguageTag(lang), v, opts...)
+}
+
+// FormatPercent 按语言格式化百分比
+//
+//	bundle.FormatPercent("en", 0.256)                       // "26%"
+//	bundle.FormatPercent("de", 0.256, gi18n.MaxFraction(1)) // "25,6 %"
+func (b *Bundle) FormatPercent(lang string, v interface{}, opts ...NumberOption) string {
+	return formatPercent(parseLanguageTag(lang), v, opts...)
+}
+
+// FormatNumber 按绑定的 Locale 格式化数字
+func (t *Translator) FormatNumber(v interface{}, opts ...NumberOption) string {
+	return formatNumber(t.locale.Tag(), v, opts...)
+}
+
+// FormatPercent 按绑定的 Locale 格式化百分比
+func (t *Translator) FormatPercent(v interface{}, opts ...NumberOption) string {
+	return formatPercent(t.locale.Tag(), v, opts...)
+}
+
+// fractionOptions 将模板函数的可选参数转换为小数位选项
+// 一个参数表示固定小数位数，两个参数表示最少和最多小数位数
+func fractionOptions(digits []int) []NumberOption {
+	switch len(digits) {
+	case 0:
+		return nil
+	case 1:
+		return []NumberOption{Fraction(digits[0])}
+	default:
+		return []NumberOption{MinFraction(digits[0]), MaxFraction(digits[1])}
+	}
+}
+
+// ========== 全局函数 ==========
+
+// FormatNumber 按语言格式化数字（全局）
+func FormatNumber(lang string, v interface{}, opts ...NumberOption) string {
+	return Default().FormatNumber(lang, v, opts...)
+}
+
+// FormatPercent 按语言格式化百分比（全局）
+func FormatPercent(lang string, v interface{}, opts ...NumberOption) string {
+	return Default().FormatPercent(lang, v, opts...)
+}
@@ -7,10 +7,11 @@ type Option func(*translateConfig)
 
 // translateConfig 翻译内部配置
 type translateConfig struct {
-	lang  string
-	data  map[string]interface{}
-	count *int
-	ctx   context.Context
+	lang   string
+	data   map[string]interface{}
+	count  *int
+	ctx    context.Context
+	locale *Locale
 }
 
 // WithLang 指定翻译目标语言
@@ -66,3 +67,15 @@ func WithContext(ctx context.Context) Option {
 		c.ctx = ctx
 	}
 }
+
+// WithLocale 指定 Locale，同时设置翻译语言，消息模板中的格式化函数使用其地区、时区和货币
+//
+//	gi18n.T("total", gi18n.WithLocale(gi18n.NewLocale("de")), gi18n.WithData("Amount", 1234.5))
+func WithLocale(l Locale) Option {
+	return func(c *translateConfig) {
+		c.locale = &l
+		if l.Lang != "" {
+			c.lang = l.Lang
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package gi18n
+
+import (
+	"net/http"
+	"strings"
+)
+
+// ========== URL 路径前缀 ==========
+
+// PathLang 返回路径首段中的已加载语言及去掉该段后的路径
+// 首段不是已加载的语言时返回空语言和原路径
+//
+//	bundle.PathLang("/zh-CN/products") // "zh-CN", "/products"
+func (b *Bundle) PathLang(path string) (string, string) {
+	trimmed := strings.TrimPrefix(path, "/")
+	segment, rest, _ := strings.Cut(trimmed, "/")
+	if segment == "" {
+		return "", path
+	}
+
+	lang := b.loadedLang(segment)
+	if lang == "" {
+		return "", path
+	}
+	return lang, "/" + rest
+}
+
+// LocalizePath 为路径加上语言前缀，已有的语言前缀会被替换
+//
+//	bundle.LocalizePath("/products", "zh-CN")    // "/zh-CN/products"
+//	bundle.LocalizePath("/en/products", "zh_CN") // "/zh-CN/products"
+func (b *Bundle) LocalizePath(path, lang string) string {
+	_, path = b.PathLang(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return "/" + normalizeLanguageTag(lang) + path
+}
+
+// AlternatePaths 返回路径在各已加载语言下的版本，可用于 hreflang 链接和语言切换
+//
+//	bundle.AlternatePaths("/en/products") // {"en": "/en/products", "zh-CN": "/zh-CN/products"}
+func (b *Bundle) AlternatePaths(path string) map[string]string {
+	langs := b.Languages()
+	result := make(map[string]string, len(langs))
+	for _, lang := range langs {
+		result[lang] = b.LocalizePath(path, lang)
+	}
+	return result
+}
+
+// loadedLang 返回与 lang 规范形式相同的已加载语言，未加载时返回空字符串
+func (b *Bundle) loadedLang(lang string) string {
+	key := canonicalLang(lang)
+	for _, l := range b.cat.Load().supported {
+		if canonicalLang(l) == key {
+			return l
+		}
+	}
+	return ""
+}
+
+// stripPathLang 去掉请求路径中的语言前缀，返回新的请求
+func stripPathLang(r *http.Request, b *Bundle) *http.Request {
+	lang, rest := b.PathLang(r.URL.Path)
+	if lang == "" {
+		return r
+	}
+
+	r2 := new(http.Request)
+	*r2 = *r
+	u := *r.URL
+	u.Path = rest
+	if u.RawPath != "" {
+		if _, rawRest := b.PathLang(u.RawPath); rawRest != u.RawPath {
+			u.RawPath = rawRest
+		} else {
+			u.RawPath = ""
+		}
+	}
+	r2.URL = &u
+	return r2
+}
+
+// ========== 全局函数 ==========
+
+// PathLang 返回路径首段中的已加载语言及剩余路径（全局）
+func PathLang(path string) (string, string) { return Default().PathLang(path) }
+
+// LocalizePath 为路径加上语言前缀（全局）
+func LocalizePath(path, lang string) string { return Default().LocalizePath(path, lang) }
+
+// AlternatePaths 返回路径在各已加载语言下的版本（全局）
+func AlternatePaths(path string) map[string]string { return Default().AlternatePaths(path) }
@@ -0,0 +1,74 @@
+package gi18n
+
+import (
+	"context"
+	"net/http"
+)
+
+// PropagationKey 语言在载体中的键名
+// 与 HTTP 的 Accept-Language 一致，下游 Middleware 可直接识别；小写形式兼容 gRPC metadata
+const PropagationKey = "accept-language"
+
+// Carrier 语言传递载体，如消息队列 header、gRPC metadata
+// 方法与 OpenTelemetry 的 TextMapCarrier 一致，已有的 TextMapCarrier 实现可直接使用
+type Carrier interface {
+	Get(key string) string
+	Set(key, value string)
+}
+
+// Inject 将 context 中的语言写入载体，context 中没有语言时不写入
+//
+//	headers := gi18n.MapCarrier{}
+//	gi18n.Inject(ctx, headers)
+func Inject(ctx context.Context, carrier Carrier) {
+	if lang, ok := ctx.Value(langCtxKey).(string); ok && lang != "" {
+		carrier.Set(PropagationKey, lang)
+	}
+}
+
+// Extract 从载体读取语言并注入 context（等同 ContextWithLang），载体中没有语言时返回原 context
+//
+//	ctx = gi18n.Extract(ctx, gi18n.MapCarrier(msg.Headers))
+func Extract(ctx context.Context, carrier Carrier) context.Context {
+	lang := parseAcceptLanguage(carrier.Get(PropagationKey))
+	if lang == "" {
+		return ctx
+	}
+	return ContextWithLang(ctx, lang)
+}
+
+// HeaderCarrier 将 http.Header 适配为 Carrier
+type HeaderCarrier http.Header
+
+// Get 实现 Carrier
+func (c HeaderCarrier) Get(key string) string { return http.Header(c).Get(key) }
+
+// Set 实现 Carrier
+func (c HeaderCarrier) Set(key, value string) { http.Header(c).Set(key, value) }
+
+// Keys 返回全部键名，兼容 OpenTelemetry 的 TextMapCarrier
+func (c HeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// MapCarrier 将 map[string]string 适配为 Carrier
+type MapCarrier map[string]string
+
+// Get 实现 Carrier
+func (c MapCarrier) Get(key string) string { return c[key] }
+
+// Set 实现 Carrier
+func (c MapCarrier) Set(key, value string) { c[key] = value }
+
+// Keys 返回全部键名，兼容 OpenTelemetry 的 TextMapCarrier
+func (c MapCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,254 @@
+package gi18n
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// relativeUnit 相对时间的单位
+type relativeUnit int
+
+const (
+	relSecond relativeUnit = iota
+	relMinute
+	relHour
+	relDay
+	relWeek
+	relMonth
+	relYear
+)
+
+// relativeUnitNames 单位名称，用于语言包中的覆盖 key
+var relativeUnitNames = [...]string{"second", "minute", "hour", "day", "week", "month", "year"}
+
+// 语言包中覆盖内置短语的 key
+//
+//	gi18n.relative.now            现在
+//	gi18n.relative.minute.past    {{.Count}} 分钟前
+//	gi18n.relative.minute.future  {{.Count}} 分钟后
+const relativeKeyPrefix = "gi18n.relative."
+
+// relativePhrases 单个语言的内置相对时间短语
+type relativePhrases struct {
+	now    string
+	past   string               // 过去，%s 为带数量的单位
+	future string               // 将来，%s 为带数量的单位
+	units  [7]map[string]string // 按单位和复数形式的短语，%d 为数量
+}
+
+// pluralForms 只区分 one 和 other 的复数短语
+func pluralForms(one, other string) map[string]string {
+	return map[string]string{"one": one, "other": other}
+}
+
+// otherForm 不区分单复数的短语
+func otherForm(other string) map[string]string {
+	return map[string]string{"other": other}
+}
+
+// russianForms 俄语的 one/few/many 复数短语
+func russianForms(one, few, many string) map[string]string {
+	return map[string]string{"one": one, "few": few, "many": many, "other": few}
+}
+
+// relativeLocales 内置的相对时间短语
+var relativeLocales = map[string]*relativePhrases{
+	"en": {now: "now", past: "%s ago", future: "in %s", units: [7]map[string]string{
+		pluralForms("%d second", "%d seconds"),
+		pluralForms("%d minute", "%d minutes"),
+		pluralForms("%d hour", "%d hours"),
+		pluralForms("%d day", "%d days"),
+		pluralForms("%d week", "%d weeks"),
+		pluralForms("%d month", "%d months"),
+		pluralForms("%d year", "%d years"),
+	}},
+	"zh": {now: "现在", past: "%s前", future: "%s后", units: [7]map[string]string{
+		otherForm("%d秒"), otherForm("%d分钟"), otherForm("%d小时"), otherForm("%d天"),
+		otherForm("%d周"), otherForm("%d个月"), otherForm("%d年"),
+	}},
+	"zh-Hant": {now: "現在", past: "%s前", future: "%s後", units: [7]map[string]string{
+		otherForm("%d 秒"), otherForm("%d 分鐘"), otherForm("%d 小時"), otherForm("%d 天"),
+		otherForm("%d 週"), otherForm("%d 個月"), otherForm("%d 年"),
+	}},
+	"ja": {now: "今", past: "%s前", future: "%s後", units: [7]map[string]string{
+		otherForm("%d 秒"), otherForm("%d 分"), otherForm("%d 時間"), otherForm("%d 日"),
+		otherForm("%d 週間"), otherForm("%d か月"), otherForm("%d 年"),
+	}},
+	"ko": {now: "지금", past: "%s 전", future: "%s 후", units: [7]map[string]string{
+		otherForm("%d초"), otherForm("%d분"), otherForm("%d시간"), otherForm("%d일"),
+		otherForm("%d주"), otherForm("%d개월"), otherForm("%d년"),
+	}},
+	"de": {now: "jetzt", past: "vor %s", future: "in %s", units: [7]map[string]string{
+		pluralForms("%d Sekunde", "%d Sekunden"),
+		pluralForms("%d Minute", "%d Minuten"),
+		pluralForms("%d Stunde", "%d Stunden"),
+		pluralForms("%d Tag", "%d Tagen"),
+		pluralForms("%d Woche", "%d Wochen"),
+		pluralForms("%d Monat", "%d Monaten"),
+		pluralForms("%d Jahr", "%d Jahren"),
+	}},
+	"fr": {now: "maintenant", past: "il y a %s", future: "dans %s", units: [7]map[string]string{
+		pluralForms("%d seconde", "%d secondes"),
+		pluralForms("%d minute", "%d minutes"),
+		pluralForms("%d heure", "%d heures"),
+		pluralForms("%d jour", "%d jours"),
+		pluralForms("%d semaine", "%d semaines"),
+		pluralForms("%d mois", "%d mois"),
+		pluralForms("%d an", "%d ans"),
+	}},
+	"es": {now: "ahora", past: "hace %s", future: "dentro de %s", units: [7]map[string]string{
+		pluralForms("%d segundo", "%d segundos"),
+		pluralForms("%d minuto", "%d minutos"),
+		pluralForms("%d hora", "%d horas"),
+		pluralForms("%d día", "%d días"),
+		pluralForms("%d semana", "%d semanas"),
+		pluralForms("%d mes", "%d meses"),
+		pluralForms("%d año", "%d años"),
+	}},
+	"it": {now: "ora", past: "%s fa", future: "tra %s", units: [7]map[string]string{
+		pluralForms("%d secondo", "%d secondi"),
+		pluralForms("%d minuto", "%d minuti"),
+		pluralForms("%d ora", "%d ore"),
+		pluralForms("%d giorno", "%d giorni"),
+		pluralForms("%d settimana", "%d settimane"),
+		pluralForms("%d mese", "%d mesi"),
+		pluralForms("%d anno", "%d anni"),
+	}},
+	"pt": {now: "agora", past: "há %s", future: "em %s", units: [7]map[string]string{
+		pluralForms("%d segundo", "%d segundos"),
+		pluralForms("%d minuto", "%d minutos"),
+		pluralForms("%d hora", "%d horas"),
+		pluralForms("%d dia", "%d dias"),
+		pluralForms("%d semana", "%d semanas"),
+		pluralForms("%d mês", "%d meses"),
+		pluralForms("%d ano", "%d anos"),
+	}},
+	"ru": {now: "сейчас", past: "%s назад", future: "через %s", units: [7]map[string]string{
+		russianForms("%d секунду", "%d секунды", "%d секунд"),
+		russianForms("%d минуту", "%d минуты", "%d минут"),
+		russianForms("%d час", "%d часа", "%d часов"),
+		russianForms("%d день", "%d дня", "%d дней"),
+		russianForms("%d неделю", "%d недели", "%d недель"),
+		russianForms("%d месяц", "%d месяца", "%d месяцев"),
+		russianForms("%d год", "%d года", "%d лет"),
+	}},
+}
+
+// relativeSpan 将时长拆分为单位和数量，不足 1 秒时 ok 为 false
+func relativeSpan(d time.Duration) (unit relativeUnit, n int, ok bool) {
+	if d < 0 {
+		d = -d
+	}
+	const (
+		day   = 24 * time.Hour
+		week  = 7 * day
+		month = 30 * day
+		year  = 365 * day
+	)
+	switch {
+	case d < time.Second:
+		return 0, 0, false
+	case d < time.Minute:
+		return relSecond, int(d / time.Second), true
+	case d < time.Hour:
+		return relMinute, int(d / time.Minute), true
+	case d < day:
+		return relHour, int(d / time.Hour), true
+	case d < week:
+		return relDay, int(d / day), true
+	case d < month:
+		return relWeek, int(d / week), true
+	case d < year:
+		return relMonth, int(d / month), true
+	}
+	return relYear, int(d / year), true
+}
+
+// builtinRelative 使用内置短语格式化相对时间，没有内置数据的语言使用英语
+func builtinRelative(tag language.Tag, unit relativeUnit, n int, past bool) string {
+	p, ok := lookupLocaleData(relativeLocales, tag)
+	if !ok {
+		p = relativeLocales["en"]
+	}
+
+	forms := p.units[unit]
+	phrase, ok := forms[pluralFormName(tag, n)]
+	if !ok {
+		phrase = forms["other"]
+	}
+	wrap := p.future
+	if past {
+		wrap = p.past
+	}
+	return fmt.Sprintf(wrap, fmt.Sprintf(phrase, n))
+}
+
+// RelativeTime 按语言格式化相对时间，d 为负数表示过去，正数表示将来
+//
+//	bundle.RelativeTime("en", -5*time.Minute)     // "5 minutes ago"
+//	bundle.RelativeTime("zh-CN", -5*time.Minute)  // "5分钟前"
+//	bundle.RelativeTime("de", 2*24*time.Hour)     // "in 2 Tagen"
+//
+// 内置 en、zh、zh-Hant、ja、ko、de、fr、es、it、pt、ru 的短语，
+// 可在语言包中通过 gi18n.relative.<unit>.past / future 和 gi18n.relative.now 覆盖，
+// unit 为 second、minute、hour、day、week、month、year，按 WithCount 选择复数形式:
+//
+//	{"gi18n.relative.minute.past": {"one": "a minute ago", "other": "{{.Count}} minutes ago"}}
+func (b *Bundle) RelativeTime(lang string, d time.Duration) string {
+	unit, n, ok := relativeSpan(d)
+	if !ok {
+		if text, ok := b.catalogOverride(lang, relativeKeyPrefix+"now"); ok {
+			return text
+		}
+		p, found := lookupLocaleData(relativeLocales, parseLanguageTag(lang))
+		if !found {
+			p = relativeLocales["en"]
+		}
+		return p.now
+	}
+
+	past := d < 0
+	direction := "future"
+	if past {
+		direction = "past"
+	}
+	id := relativeKeyPrefix + relativeUnitNames[unit] + "." + direction
+	if text, ok := b.catalogOverride(lang, id, WithCount(n)); ok {
+		return text
+	}
+	return builtinRelative(parseLanguageTag(lang), unit, n, past)
+}
+
+// RelativeTime 按绑定的语言格式化相对时间
+func (t *Translator) RelativeTime(d time.Duration) string {
+	return t.bundle.RelativeTime(t.lang, d)
+}
+
+// relativeFunc 消息模板中的 relative 函数，参数为 time.Duration 或 time.Time（相对当前时间）
+//
+//	{{relative .Elapsed}}  // 5 minutes ago
+//	{{relative .UpdatedAt}}
+func (b *Bundle) relativeFunc(lang string) func(v interface{}) (string, error) {
+	return func(v interface{}) (string, error) {
+		switch x := v.(type) {
+		case time.Duration:
+			return b.RelativeTime(lang, x), nil
+		case time.Time:
+			return b.RelativeTime(lang, time.Until(x)), nil
+		case *time.Time:
+			if x != nil {
+				return b.RelativeTime(lang, time.Until(*x)), nil
+			}
+		}
+		return "", fmt.Errorf("gi18n: relative: unsupported type %T", v)
+	}
+}
+
+// ========== 全局函数 ==========
+
+// RelativeTime 按语言格式化相对时间（全局）
+func RelativeTime(lang string, d time.Duration) string {
+	return Default().RelativeTime(lang, d)
+}
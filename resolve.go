@@ -0,0 +1,74 @@
+package gi18n
+
+import (
+	"math"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// Result 翻译结果及其解析过程，用于调试回退逻辑
+type Result struct {
+	ID            string // 消息 ID
+	Text          string // 返回的文本，缺失时遵循 MissPolicy
+	RequestedLang string // 请求的语言（WithLang > Context > 当前语言）
+	MatchedLang   string // 实际提供翻译的语言，失败时为空
+	Fallback      bool   // 实际语言与请求语言不同
+	Missing       bool   // 翻译缺失
+	PluralForm    string // 选中的复数形式（zero/one/two/few/many/other），未使用 WithCount 时为空
+	Err           error  // 失败原因，与 Lookup 返回的错误相同
+}
+
+// Resolve 翻译并返回解析过程，选项与 T() 相同
+//
+//	r := bundle.Resolve("hello", WithLang("zh-TW"))
+//	// r.RequestedLang = "zh-TW", r.MatchedLang = "en", r.Fallback = true
+//
+// Resolve 不触发 MissHandler / Logger，适合用于调试页面
+func (b *Bundle) Resolve(id string, opts ...Option) Result {
+	r := b.localize(id, opts)
+	if r.Err != nil {
+		r.Text = b.missText(id)
+	}
+	return *r
+}
+
+// pluralFormNames 复数形式名称，与语言文件中的字段一致
+var pluralFormNames = map[plural.Form]string{
+	plural.Zero:  "zero",
+	plural.One:   "one",
+	plural.Two:   "two",
+	plural.Few:   "few",
+	plural.Many:  "many",
+	plural.Other: "other",
+}
+
+// pluralFormName 按语言的复数规则计算整数计数对应的复数形式
+func pluralFormName(tag language.Tag, count int) string {
+	n := count
+	if n < 0 {
+		n = -n
+	}
+	return pluralFormNames[plural.Cardinal.MatchPlural(tag, n, 0, 0, 0, 0)]
+}
+
+// decimalPluralForm 按语言的复数规则计算小数对应的复数形式，digits 为最多显示的小数位数
+// 如 en 中 1 -> one、1.5 -> other，fr 中 1.5 -> one
+// NaN、±Inf 和超出 int64 范围的值一律为 other
+func decimalPluralForm(tag language.Tag, v float64, digits int) string {
+	scale := int(math.Pow10(digits))
+	rounded := math.Round(math.Abs(v) * float64(scale))
+	if math.IsNaN(rounded) || rounded >= math.MaxInt64 {
+		return "other"
+	}
+	scaled := int(rounded)
+	i, f := scaled/scale, scaled%scale
+
+	// 去掉小数末尾的 0，与显示的数字一致
+	visible := digits
+	for visible > 0 && f%10 == 0 {
+		f /= 10
+		visible--
+	}
+	return pluralFormNames[plural.Cardinal.MatchPlural(tag, i, visible, visible, f, f)]
+}
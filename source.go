@@ -0,0 +1,91 @@
+package gi18n
+
+import (
+	"net/http"
+	"sync"
+)
+
+// SourceFunc 自定义语言来源，返回请求中的语言，没有时返回空字符串
+// 返回值与内置来源一样会和已加载的语言协商，可以是完整的 Accept-Language 格式
+type SourceFunc func(r *http.Request) string
+
+// 自定义来源注册表
+var (
+	sourcesMu     sync.RWMutex
+	sourceFuncs   = make(map[LangSource]SourceFunc)
+	namedSources  = make(map[string]LangSource)
+	sourceHeaders = make(map[LangSource]string) // 读取请求头的来源及其头名，用于 Vary
+	nextSource    = LangSource(100)             // 为内置来源预留前 100 个值
+)
+
+// RegisterSource 注册自定义语言来源，返回可放入 MiddlewareConfig.Sources 的 LangSource
+//
+//	jwtSource := gi18n.RegisterSource(func(r *http.Request) string {
+//	    return claimsFrom(r).Locale
+//	})
+//	cfg.Sources = []gi18n.LangSource{gi18n.SourceQuery, jwtSource, gi18n.SourceHeader}
+func RegisterSource(fn SourceFunc) LangSource {
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+	return registerSourceLocked(fn)
+}
+
+// registerSourceLocked 分配新的来源值，调用方需持有 sourcesMu
+func registerSourceLocked(fn SourceFunc) LangSource {
+	source := nextSource
+	nextSource++
+	sourceFuncs[source] = fn
+	return source
+}
+
+// registerNamedSource 按名称注册来源，同名来源只注册一次
+func registerNamedSource(name string, fn SourceFunc) LangSource {
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+	if source, ok := namedSources[name]; ok {
+		return source
+	}
+	source := registerSourceLocked(fn)
+	namedSources[name] = source
+	return source
+}
+
+// HeaderSource 从指定请求头读取语言的来源
+//
+//	cfg.Sources = []gi18n.LangSource{gi18n.HeaderSource("X-App-Locale"), gi18n.SourceHeader}
+func HeaderSource(name string) LangSource {
+	key := http.CanonicalHeaderKey(name)
+	source := registerNamedSource("header:"+key, func(r *http.Request) string {
+		return r.Header.Get(key)
+	})
+
+	sourcesMu.Lock()
+	sourceHeaders[source] = key
+	sourcesMu.Unlock()
+	return source
+}
+
+// FormSource 从表单值读取语言的来源（URL 参数或 POST 表单，会解析请求体）
+//
+//	cfg.Sources = []gi18n.LangSource{gi18n.FormSource("locale"), gi18n.SourceCookie}
+func FormSource(key string) LangSource {
+	return registerNamedSource("form:"+key, func(r *http.Request) string {
+		return r.FormValue(key)
+	})
+}
+
+// sourceHeader 获取 HeaderSource 来源读取的请求头名
+func sourceHeader(source LangSource) (string, bool) {
+	sourcesMu.RLock()
+	defer sourcesMu.RUnlock()
+	name, ok := sourceHeaders[source]
+	return name, ok
+}
+
+// customSource 获取已注册的自定义来源
+func customSource(source LangSource) (SourceFunc, bool) {
+	sourcesMu.RLock()
+	defer sourcesMu.RUnlock()
+	fn, ok := sourceFuncs[source]
+	return fn, ok
+}
@@ -0,0 +1,91 @@
+package gi18n
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ========== 语言切换 ==========
+
+// SwitchHandler 创建语言切换 handler
+//
+//	mux.Handle("/lang", gi18n.SwitchHandler(nil))
+//	// GET /lang?lang=ja&return=/products
+//
+// 校验语言是否已在 cfg.Bundle 中加载（未加载返回 400），写入 Middleware 使用的语言 Cookie，
+// 然后重定向回 return 参数指定的站内路径；return 缺失或指向其它站点时重定向到 "/"。
+// 配置中启用 SourcePath 时，返回路径会替换为对应语言的前缀。
+func SwitchHandler(cfg *MiddlewareConfig) http.Handler {
+	if cfg == nil {
+		cfg = DefaultMiddlewareConfig()
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b := cfg.bundle()
+		lang := b.loadedLang(r.FormValue(cfg.QueryParam))
+		if lang == "" {
+			http.Error(w, "unsupported language", http.StatusBadRequest)
+			return
+		}
+
+		setLangCookie(w, cfg, lang)
+
+		target := safeReturnPath(r.FormValue("return"))
+		if hasSource(cfg, SourcePath) {
+			target = b.LocalizePath(target, lang)
+		}
+		http.Redirect(w, r, target, http.StatusSeeOther)
+	})
+}
+
+// RootRedirect 创建根路径重定向中间件，将 "/" 重定向到 "/{协商语言}/"
+// 适用于使用 URL 路径前缀的站点，其它路径原样交给下一个 handler
+//
+//	mux.Handle("/", gi18n.RootRedirect(cfg)(gi18n.Middleware(cfg)(handler)))
+func RootRedirect(cfg *MiddlewareConfig) func(http.Handler) http.Handler {
+	if cfg == nil {
+		cfg = DefaultMiddlewareConfig()
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			lang := detectLanguage(r, cfg)
+			if cfg.Vary {
+				addSourceVary(w.Header(), cfg.Sources)
+			}
+			target := "/" + lang + "/"
+			if r.URL.RawQuery != "" {
+				target += "?" + r.URL.RawQuery
+			}
+			http.Redirect(w, r, target, http.StatusFound)
+		})
+	}
+}
+
+// safeReturnPath 校验重定向目标，只允许站内路径，防止开放重定向
+func safeReturnPath(target string) string {
+	if target == "" || !strings.HasPrefix(target, "/") {
+		return "/"
+	}
+	// 拒绝 //evil.com 等协议相对地址
+	if len(target) > 1 && target[1] == '/' {
+		return "/"
+	}
+	// 浏览器会忽略制表符、换行等控制字符并把 \ 当作 /，/%09/evil.com 和 /\evil.com 都会跳到其它站点
+	for i := 0; i < len(target); i++ {
+		if c := target[i]; c < 0x20 || c == 0x7f || c == '\\' {
+			return "/"
+		}
+	}
+	u, err := url.Parse(target)
+	if err != nil || u.Scheme != "" || u.Host != "" {
+		return "/"
+	}
+	return target
+}
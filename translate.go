@@ -1,6 +1,10 @@
 package gi18n
 
 import (
+	"errors"
+	"strings"
+	"text/template"
+
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 )
 
@@ -22,10 +26,9 @@ func (b *Bundle) GetLang() string {
 
 // Languages 获取支持的语言列表
 func (b *Bundle) Languages() []string {
-	b.mu.RLock()
-	defer b.mu.RUnlock()
-	result := make([]string, len(b.supported))
-	copy(result, b.supported)
+	supported := b.cat.Load().supported
+	result := make([]string, len(supported))
+	copy(result, supported)
 	return result
 }
 
@@ -39,8 +42,8 @@ func (b *Bundle) SetDefaultLang(lang string) {
 // SetFallbackLang 设置回退语言
 func (b *Bundle) SetFallbackLang(lang string) {
 	b.mu.Lock()
-	defer b.mu.Unlock()
 	b.fallbackLang = normalizeLanguageTag(lang)
+	b.mu.Unlock()
 	b.clearLocalizerCache()
 }
 
@@ -72,12 +75,46 @@ func (b *Bundle) SetFallbackLang(lang string) {
 //
 //	bundle.T("hello", WithContext(ctx))
 func (b *Bundle) T(id string, opts ...Option) string {
-	tc := &translateConfig{}
-	for _, opt := range opts {
-		opt(tc)
+	r := b.localize(id, opts)
+	if r.Err != nil {
+		b.handleMiss(r.RequestedLang, id)
+		return b.missText(id)
 	}
+	return r.Text
+}
+
+// Lookup 翻译并返回错误，选项与 T() 相同
+//
+// 与 T() 不同，失败时返回空字符串和具体错误，且不触发 MissHandler / Logger：
+//
+//	msg, err := bundle.Lookup("greeting", WithData("Name", "张三"))
+//	switch {
+//	case errors.Is(err, ErrMessageNotFound): // *NotFoundError
+//	case errors.Is(err, ErrTemplate):        // *TemplateError
+//	case errors.Is(err, ErrPlural):          // *PluralError
+//	}
+func (b *Bundle) Lookup(id string, opts ...Option) (string, error) {
+	if id == "" {
+		return "", ErrEmptyID
+	}
+	r := b.localize(id, opts)
+	if r.Err != nil {
+		return "", r.Err
+	}
+	return r.Text, nil
+}
 
-	// 语言优先级: 显式指定 > Context > 当前语言
+// missText 按 MissPolicy 返回缺失时的文本
+func (b *Bundle) missText(id string) string {
+	if b.missPolicy == MissReturnEmpty {
+		return ""
+	}
+	return id
+}
+
+// resolveLang 确定翻译目标语言
+// 语言优先级: 显式指定 > Context > 当前语言
+func (b *Bundle) resolveLang(tc *translateConfig) string {
 	lang := b.GetLang()
 	if tc.ctx != nil {
 		if ctxLang, ok := tc.ctx.Value(langCtxKey).(string); ok && ctxLang != "" {
@@ -87,6 +124,30 @@ func (b *Bundle) T(id string, opts ...Option) string {
 	if tc.lang != "" {
 		lang = tc.lang
 	}
+	return lang
+}
+
+// resolveLocale 确定消息模板格式化使用的 Locale
+// 优先级: WithLocale > Context 中的 Locale > 由目标语言推导
+func (b *Bundle) resolveLocale(tc *translateConfig, lang string) Locale {
+	var l Locale
+	if tc.locale != nil {
+		l = *tc.locale
+	} else if tc.ctx != nil {
+		l, _ = tc.ctx.Value(localeCtxKey{}).(Locale)
+	}
+	return localeForLang(l, lang)
+}
+
+// localize 执行翻译，返回包含解析过程的结果
+// 失败时 Text 为空，Err 为分类后的错误
+func (b *Bundle) localize(id string, opts []Option) *Result {
+	tc := &translateConfig{}
+	for _, opt := range opts {
+		opt(tc)
+	}
+	lang := b.resolveLang(tc)
+	r := &Result{ID: id, RequestedLang: normalizeLanguageTag(lang)}
 
 	// 构建 LocalizeConfig
 	lc := &i18n.LocalizeConfig{
@@ -106,16 +167,47 @@ func (b *Bundle) T(id string, opts ...Option) string {
 		}
 	}
 
-	loc := b.getLocalizer(lang)
-	msg, err := loc.Localize(lc)
+	lc.TemplateParser = b.templateParser(b.resolveLocale(tc, lang))
+
+	msg, tag, err := b.localizeChain(lang, lc)
 	if err != nil {
-		b.handleMiss(lang, id)
-		if b.missPolicy == MissReturnEmpty {
-			return ""
-		}
-		return id
+		r.Err = classifyError(r.RequestedLang, id, tc.count, err)
+		r.Missing = errors.Is(r.Err, ErrMessageNotFound)
+		return r
+	}
+
+	r.Text = msg
+	r.MatchedLang = tag.String()
+	r.Fallback = r.MatchedLang != parseLanguageTag(lang).String()
+	if tc.count != nil {
+		r.PluralForm = pluralFormName(tag, *tc.count)
+	}
+	return r
+}
+
+// classifyError 将 go-i18n 的错误转换为 gi18n 的错误类型
+//
+// go-i18n 的复数相关错误类型未导出，只能按其固定的错误信息识别；
+// 模板执行错误（text/template.ExecError）和解析错误均视为模板错误
+func classifyError(lang, id string, count *int, err error) error {
+	var notFound *i18n.MessageNotFoundErr
+	if errors.As(err, &notFound) {
+		return &NotFoundError{Lang: lang, ID: id}
+	}
+	var execErr template.ExecError
+	if errors.As(err, &execErr) {
+		return &TemplateError{Lang: lang, ID: id, Err: err}
 	}
-	return msg
+	if count != nil && isPluralError(err) {
+		return &PluralError{Lang: lang, ID: id, Count: *count, Err: err}
+	}
+	return &TemplateError{Lang: lang, ID: id, Err: err}
+}
+
+// isPluralError 判断是否为 go-i18n 的复数错误：消息缺少复数形式或计数无效
+func isPluralError(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "has no plural form") || strings.HasPrefix(msg, "invalid plural count")
 }
 
 // ========== 全局核心函数 ==========
@@ -142,8 +234,37 @@ func SetFallbackLang(lang string) { Default().SetFallbackLang(lang) }
 //	gi18n.T("greeting", gi18n.WithData("Name", "张三"))
 //	gi18n.T("items", gi18n.WithCount(5))
 //	gi18n.T("hello", gi18n.WithContext(ctx))
+//
+// 传入 WithContext 时使用 context 中的 Bundle（见 ContextWithBundle），没有时使用全局实例
 func T(id string, opts ...Option) string {
-	return Default().T(id, opts...)
+	return optionBundle(opts).T(id, opts...)
+}
+
+// Lookup 翻译并返回错误（全局）
+//
+//	msg, err := gi18n.Lookup("greeting", gi18n.WithData("Name", "张三"))
+func Lookup(id string, opts ...Option) (string, error) {
+	return optionBundle(opts).Lookup(id, opts...)
+}
+
+// Resolve 翻译并返回解析过程（全局）
+//
+//	r := gi18n.Resolve("hello", gi18n.WithLang("zh-TW"))
+func Resolve(id string, opts ...Option) Result {
+	return optionBundle(opts).Resolve(id, opts...)
+}
+
+// optionBundle 返回全局翻译函数使用的 Bundle
+// 选项中有 context 时使用其中的 Bundle，否则使用全局实例
+func optionBundle(opts []Option) *Bundle {
+	tc := &translateConfig{}
+	for _, opt := range opts {
+		opt(tc)
+	}
+	if tc.ctx != nil {
+		return BundleFromContext(tc.ctx)
+	}
+	return Default()
 }
 
 // ========== 已废弃的实例方法（向后兼容） ==========
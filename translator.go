@@ -0,0 +1,97 @@
+package gi18n
+
+import "context"
+
+// Translator 绑定到单一语言的翻译器
+//
+// Translator 不可变、创建开销很小，适合在每个请求中使用，
+// 避免通过全局 SetLang 修改进程级状态：
+//
+//	tr := gi18n.FromContext(r.Context())
+//	tr.T("hello")
+//	tr.T("greeting", gi18n.WithData("Name", "张三"))
+type Translator struct {
+	bundle *Bundle
+	lang   string
+	locale Locale
+}
+
+// For 返回绑定到指定语言的 Translator
+//
+//	tr := bundle.For("zh-CN")
+func (b *Bundle) For(lang string) *Translator {
+	return b.ForLocale(NewLocale(lang))
+}
+
+// ForLocale 返回绑定到指定 Locale 的 Translator，格式化方法使用其地区、时区和货币
+func (b *Bundle) ForLocale(l Locale) *Translator {
+	l.Lang = normalizeLanguageTag(l.Lang)
+	return &Translator{bundle: b, lang: l.Lang, locale: l.withDefaults()}
+}
+
+// FromContext 返回绑定到 context 中语言和 Locale 的 Translator
+// context 中没有语言信息时使用 Bundle 的当前语言，语言在创建时确定
+// context 中的语言与 Locale 不一致时（如之后又调用了 ContextWithLang），地区和货币按语言重新推导
+func (b *Bundle) FromContext(ctx context.Context) *Translator {
+	tc := &translateConfig{ctx: ctx}
+	l := b.resolveLocale(tc, b.resolveLang(tc))
+	return &Translator{bundle: b, lang: l.Lang, locale: l}
+}
+
+// Has 判断消息在目标语言（含回退链）中是否存在
+//
+//	bundle.Has("hello", WithLang("zh-CN"))
+func (b *Bundle) Has(id string, opts ...Option) bool {
+	if id == "" {
+		return false
+	}
+	return !b.localize(id, opts).Missing
+}
+
+// Lang 返回 Translator 绑定的语言
+func (t *Translator) Lang() string { return t.lang }
+
+// Locale 返回 Translator 绑定的 Locale
+func (t *Translator) Locale() Locale { return t.locale }
+
+// Bundle 返回 Translator 所属的 Bundle
+func (t *Translator) Bundle() *Bundle { return t.bundle }
+
+// T 使用绑定的语言翻译，选项与 Bundle.T 相同
+func (t *Translator) T(id string, opts ...Option) string {
+	return t.bundle.T(id, t.options(opts)...)
+}
+
+// Lookup 使用绑定的语言翻译并返回错误
+func (t *Translator) Lookup(id string, opts ...Option) (string, error) {
+	return t.bundle.Lookup(id, t.options(opts)...)
+}
+
+// Resolve 使用绑定的语言翻译并返回解析过程
+func (t *Translator) Resolve(id string, opts ...Option) Result {
+	return t.bundle.Resolve(id, t.options(opts)...)
+}
+
+// Has 判断消息在绑定的语言（含回退链）中是否存在
+func (t *Translator) Has(id string, opts ...Option) bool {
+	return t.bundle.Has(id, t.options(opts)...)
+}
+
+// options 在调用方选项前加上绑定的语言和 Locale
+func (t *Translator) options(opts []Option) []Option {
+	return append([]Option{WithLocale(t.locale)}, opts...)
+}
+
+// ========== 全局函数 ==========
+
+// For 返回绑定到指定语言的 Translator（全局）
+func For(lang string) *Translator { return Default().For(lang) }
+
+// FromContext 返回绑定到 context 中语言的 Translator
+// 使用 context 中的 Bundle（见 ContextWithBundle），没有时使用全局实例
+//
+//	tr := gi18n.FromContext(r.Context())
+func FromContext(ctx context.Context) *Translator { return BundleFromContext(ctx).FromContext(ctx) }
+
+// Has 判断消息是否存在（全局）
+func Has(id string, opts ...Option) bool { return optionBundle(opts).Has(id, opts...) }
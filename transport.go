@@ -0,0 +1,52 @@
+package gi18n
+
+import "net/http"
+
+// Transport 将 context 中的语言传递给下游服务的 http.RoundTripper
+//
+//	client := &http.Client{Transport: gi18n.NewTransport(nil)}
+//	req, _ := http.NewRequestWithContext(r.Context(), "GET", url, nil)
+//	client.Do(req) // 自动带上 Accept-Language
+//
+// 仅当 context 中有语言（ContextWithLang / Middleware）时设置请求头，
+// 调用方已设置的请求头不会被覆盖
+type Transport struct {
+	// Base 实际发送请求的 RoundTripper，默认 http.DefaultTransport
+	Base http.RoundTripper
+	// Header 额外写入语言的自定义请求头（可选），如 "X-App-Locale"
+	Header string
+}
+
+// NewTransport 创建传递语言的 Transport
+func NewTransport(base http.RoundTripper) *Transport {
+	return &Transport{Base: base}
+}
+
+// RoundTrip 实现 http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	lang, ok := req.Context().Value(langCtxKey).(string)
+	if !ok || lang == "" {
+		return base.RoundTrip(req)
+	}
+
+	setAccept := req.Header.Get("Accept-Language") == ""
+	setCustom := t.Header != "" && req.Header.Get(t.Header) == ""
+	if !setAccept && !setCustom {
+		return base.RoundTrip(req)
+	}
+
+	// RoundTripper 不应修改原请求
+	r2 := req.Clone(req.Context())
+	if setAccept {
+		r2.Header.Set("Accept-Language", lang)
+	}
+	if setCustom {
+		r2.Header.Set(t.Header, lang)
+	}
+	return base.RoundTrip(r2)
+}
@@ -0,0 +1,283 @@
+package gi18n
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// unitKind 内置的计量单位
+type unitKind int
+
+const (
+	unitDay unitKind = iota
+	unitHour
+	unitMinute
+	unitSecond
+	unitMeter
+	unitKilometer
+)
+
+// unitPhrases 单个单位的短格式和长格式，按复数形式，%s 为数字
+type unitPhrases struct {
+	short map[string]string
+	long  map[string]string
+}
+
+// unitSymbols 单个语言的单位数据
+type unitSymbols struct {
+	units [6]unitPhrases
+	bytes [6]string // B、kB、MB、GB、TB、PB 的格式，%s 为数字
+}
+
+// 常用的字节单位格式
+var (
+	latinBytes = [6]string{"%s B", "%s kB", "%s MB", "%s GB", "%s TB", "%s PB"}
+	cjkBytes   = [6]string{"%sB", "%skB", "%sMB", "%sGB", "%sTB", "%sPB"}
+)
+
+// sameUnit 短格式和长格式相同的单位
+func sameUnit(phrase string) unitPhrases {
+	return unitPhrases{short: otherForm(phrase), long: otherForm(phrase)}
+}
+
+// unitLocales 内置的单位数据（参考 CLDR）
+var unitLocales = map[string]*unitSymbols{
+	"en": {
+		units: [6]unitPhrases{
+			{pluralForms("%s day", "%s days"), pluralForms("%s day", "%s days")},
+			{otherForm("%s hr"), pluralForms("%s hour", "%s hours")},
+			{otherForm("%s min"), pluralForms("%s minute", "%s minutes")},
+			{otherForm("%s sec"), pluralForms("%s second", "%s seconds")},
+			{otherForm("%s m"), pluralForms("%s meter", "%s meters")},
+			{otherForm("%s km"), pluralForms("%s kilometer", "%s kilometers")},
+		},
+		bytes: latinBytes,
+	},
+	"zh": {
+		units: [6]unitPhrases{
+			sameUnit("%s天"), sameUnit("%s小时"), sameUnit("%s分钟"),
+			sameUnit("%s秒"), sameUnit("%s米"), sameUnit("%s公里"),
+		},
+		bytes: cjkBytes,
+	},
+	"zh-Hant": {
+		units: [6]unitPhrases{
+			sameUnit("%s 天"), sameUnit("%s 小時"), sameUnit("%s 分鐘"),
+			sameUnit("%s 秒"), sameUnit("%s 公尺"), sameUnit("%s 公里"),
+		},
+		bytes: latinBytes,
+	},
+	"ja": {
+		units: [6]unitPhrases{
+			sameUnit("%s 日"), sameUnit("%s 時間"), sameUnit("%s 分"), sameUnit("%s 秒"),
+			{otherForm("%s m"), otherForm("%s メートル")},
+			{otherForm("%s km"), otherForm("%s キロメートル")},
+		},
+		bytes: latinBytes,
+	},
+	"ko": {
+		units: [6]unitPhrases{
+			sameUnit("%s일"), sameUnit("%s시간"), sameUnit("%s분"), sameUnit("%s초"),
+			{otherForm("%sm"), otherForm("%s미터")},
+			{otherForm("%skm"), otherForm("%s킬로미터")},
+		},
+		bytes: cjkBytes,
+	},
+	"de": {
+		units: [6]unitPhrases{
+			{otherForm("%s Tg."), pluralForms("%s Tag", "%s Tage")},
+			{otherForm("%s Std."), pluralForms("%s Stunde", "%s Stunden")},
+			{otherForm("%s Min."), pluralForms("%s Minute", "%s Minuten")},
+			{otherForm("%s Sek."), pluralForms("%s Sekunde", "%s Sekunden")},
+			{otherForm("%s m"), otherForm("%s Meter")},
+			{otherForm("%s km"), otherForm("%s Kilometer")},
+		},
+		bytes: latinBytes,
+	},
+	"fr": {
+		units: [6]unitPhrases{
+			{otherForm("%s j"), pluralForms("%s jour", "%s jours")},
+			{otherForm("%s h"), pluralForms("%s heure", "%s heures")},
+			{otherForm("%s min"), pluralForms("%s minute", "%s minutes")},
+			{otherForm("%s s"), pluralForms("%s seconde", "%s secondes")},
+			{otherForm("%s m"), pluralForms("%s mètre", "%s mètres")},
+			{otherForm("%s km"), pluralForms("%s kilomètre", "%s kilomètres")},
+		},
+		bytes: [6]string{"%s o", "%s ko", "%s Mo", "%s Go", "%s To", "%s Po"},
+	},
+	"es": {
+		units: [6]unitPhrases{
+			{otherForm("%s d"), pluralForms("%s día", "%s días")},
+			{otherForm("%s h"), pluralForms("%s hora", "%s horas")},
+			{otherForm("%s min"), pluralForms("%s minuto", "%s minutos")},
+			{otherForm("%s s"), pluralForms("%s segundo", "%s segundos")},
+			{otherForm("%s m"), pluralForms("%s metro", "%s metros")},
+			{otherForm("%s km"), pluralForms("%s kilómetro", "%s kilómetros")},
+		},
+		bytes: latinBytes,
+	},
+	"it": {
+		units: [6]unitPhrases{
+			{otherForm("%s g"), pluralForms("%s giorno", "%s giorni")},
+			{otherForm("%s h"), pluralForms("%s ora", "%s ore")},
+			{otherForm("%s min"), pluralForms("%s minuto", "%s minuti")},
+			{otherForm("%s s"), pluralForms("%s secondo", "%s secondi")},
+			{otherForm("%s m"), pluralForms("%s metro", "%s metri")},
+			{otherForm("%s km"), pluralForms("%s chilometro", "%s chilometri")},
+		},
+		bytes: latinBytes,
+	},
+	"pt": {
+		units: [6]unitPhrases{
+			{pluralForms("%s dia", "%s dias"), pluralForms("%s dia", "%s dias")},
+			{otherForm("%s h"), pluralForms("%s hora", "%s horas")},
+			{otherForm("%s min"), pluralForms("%s minuto", "%s minutos")},
+			{otherForm("%s s"), pluralForms("%s segundo", "%s segundos")},
+			{otherForm("%s m"), pluralForms("%s metro", "%s metros")},
+			{otherForm("%s km"), pluralForms("%s quilômetro", "%s quilômetros")},
+		},
+		bytes: latinBytes,
+	},
+	"ru": {
+		units: [6]unitPhrases{
+			{otherForm("%s дн."), russianForms("%s день", "%s дня", "%s дней")},
+			{otherForm("%s ч"), russianForms("%s час", "%s часа", "%s часов")},
+			{otherForm("%s мин"), russianForms("%s минута", "%s минуты", "%s минут")},
+			{otherForm("%s с"), russianForms("%s секунда", "%s секунды", "%s секунд")},
+			{otherForm("%s м"), russianForms("%s метр", "%s метра", "%s метров")},
+			{otherForm("%s км"), russianForms("%s километр", "%s километра", "%s километров")},
+		},
+		bytes: [6]string{"%s Б", "%s кБ", "%s МБ", "%s ГБ", "%s ТБ", "%s ПБ"},
+	},
+}
+
+// lookupUnitSymbols 查找语言的单位数据，没有内置数据的语言使用英语
+func lookupUnitSymbols(tag language.Tag) *unitSymbols {
+	if s, ok := lookupLocaleData(unitLocales, tag); ok {
+		return s
+	}
+	return unitLocales["en"]
+}
+
+// formatUnit 按语言格式化带单位的数量，digits 为最多显示的小数位数
+func formatUnit(tag language.Tag, kind unitKind, v float64, digits int, style UnitStyle) string {
+	u := lookupUnitSymbols(tag).units[kind]
+	phrases := u.short
+	if style == UnitLong {
+		phrases = u.long
+	}
+	num := formatNumber(tag, v, MaxFraction(digits))
+	return fmt.Sprintf(pickForm(phrases, decimalPluralForm(tag, v, digits)), num)
+}
+
+// formatBytes 按语言格式化字节数，使用十进制单位（1 kB = 1000 B）
+func formatBytes(tag language.Tag, n int64) string {
+	symbols := lookupUnitSymbols(tag).bytes
+	v := float64(n)
+	i := 0
+	for i < len(symbols)-1 && math.Abs(roundTo(v, 1)) >= 1000 {
+		v /= 1000
+		i++
+	}
+	digits := 1
+	if i == 0 {
+		digits = 0
+	}
+	return fmt.Sprintf(symbols[i], formatNumber(tag, v, MaxFraction(digits)))
+}
+
+// formatDistance 按语言格式化距离，不足 1 公里时使用米
+func formatDistance(tag language.Tag, meters float64, style UnitStyle) string {
+	if math.Abs(math.Round(meters)) < 1000 {
+		return formatUnit(tag, unitMeter, math.Round(meters), 0, style)
+	}
+	return formatUnit(tag, unitKilometer, meters/1000, 1, style)
+}
+
+// FormatBytes 按语言格式化字节数，使用十进制单位（1 kB = 1000 B）
+//
+//	bundle.FormatBytes("en", 3400000000) // "3.4 GB"
+//	bundle.FormatBytes("fr", 3400000000) // "3,4 Go"
+func (b *Bundle) FormatBytes(lang string, n int64) string {
+	return formatBytes(parseLanguageTag(lang), n)
+}
+
+// FormatDuration 按语言格式化时长，拆分为天、小时、分钟、秒，省略为 0 的部分
+//
+//	bundle.FormatDuration("en", 90*time.Minute, gi18n.UnitShort)   // "1 hr, 30 min"
+//	bundle.FormatDuration("en", 90*time.Minute, gi18n.UnitLong)    // "1 hour, 30 minutes"
+//	bundle.FormatDuration("zh-CN", 90*time.Minute, gi18n.UnitLong) // "1小时30分钟"
+//
+// 各部分按 ListUnit 风格连接，不足 1 秒时显示为 0 秒
+func (b *Bundle) FormatDuration(lang string, d time.Duration, style UnitStyle) string {
+	tag := parseLanguageTag(lang)
+	if d < 0 {
+		d = -d
+	}
+
+	parts := make([]string, 0, 4)
+	rest := d
+	for _, p := range []struct {
+		kind unitKind
+		size time.Duration
+	}{
+		{unitDay, 24 * time.Hour},
+		{unitHour, time.Hour},
+		{unitMinute, time.Minute},
+		{unitSecond, time.Second},
+	} {
+		n := rest / p.size
+		rest -= n * p.size
+		if n > 0 {
+			parts = append(parts, formatUnit(tag, p.kind, float64(n), 0, style))
+		}
+	}
+	if len(parts) == 0 {
+		return formatUnit(tag, unitSecond, 0, 0, style)
+	}
+	return b.FormatList(lang, parts, ListUnit)
+}
+
+// FormatDistance 按语言格式化距离（米），不足 1 公里时使用米，否则使用公里
+//
+//	bundle.FormatDistance("en", 850, gi18n.UnitShort)  // "850 m"
+//	bundle.FormatDistance("en", 3400, gi18n.UnitLong)  // "3.4 kilometers"
+//	bundle.FormatDistance("de", 3400, gi18n.UnitShort) // "3,4 km"
+func (b *Bundle) FormatDistance(lang string, meters float64, style UnitStyle) string {
+	return formatDistance(parseLanguageTag(lang), meters, style)
+}
+
+// FormatBytes 按绑定的 Locale 格式化字节数
+func (t *Translator) FormatBytes(n int64) string {
+	return formatBytes(t.locale.Tag(), n)
+}
+
+// FormatDuration 按绑定的语言格式化时长
+func (t *Translator) FormatDuration(d time.Duration, style UnitStyle) string {
+	return t.bundle.FormatDuration(t.lang, d, style)
+}
+
+// FormatDistance 按绑定的 Locale 格式化距离
+func (t *Translator) FormatDistance(meters float64, style UnitStyle) string {
+	return formatDistance(t.locale.Tag(), meters, style)
+}
+
+// ========== 全局函数 ==========
+
+// FormatBytes 按语言格式化字节数（全局）
+func FormatBytes(lang string, n int64) string {
+	return Default().FormatBytes(lang, n)
+}
+
+// FormatDuration 按语言格式化时长（全局）
+func FormatDuration(lang string, d time.Duration, style UnitStyle) string {
+	return Default().FormatDuration(lang, d, style)
+}
+
+// FormatDistance 按语言格式化距离（全局）
+func FormatDistance(lang string, meters float64, style UnitStyle) string {
+	return Default().FormatDistance(lang, meters, style)
+}
@@ -0,0 +1,119 @@
+package gi18n
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// defaultWatchInterval Watch 默认轮询间隔
+const defaultWatchInterval = time.Second
+
+// fileStamp 文件状态快照，用于判断文件是否变化
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// Watch 监听目录中语言文件的新增、修改和删除，发生变化时自动重新加载
+//
+//	bundle.Load("./locales")
+//	bundle.Watch(ctx, "./locales")
+//
+// 目录尚未通过 Load 加载时会先加载一次，加载失败直接返回错误。
+// 监听在后台以 Config.WatchInterval 为间隔轮询，ctx 取消后停止。
+// 重新加载通过 Reload 基于全部加载来源构建新的翻译目录并原子替换；
+// 解析失败时保留上一份可用的翻译，并通过 Config.Logger 报告错误。
+func (b *Bundle) Watch(ctx context.Context, dir string) error {
+	if !b.cat.Load().hasDirSource(dir) {
+		if err := b.Load(dir); err != nil {
+			return err
+		}
+	}
+
+	stamps, err := scanDir(dir)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(b.watchInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			current, err := scanDir(dir)
+			if err != nil {
+				b.warn("gi18n: failed to scan directory", "dir", dir, "error", err)
+				continue
+			}
+			if sameStamps(stamps, current) {
+				continue
+			}
+			stamps = current
+
+			if err := b.Reload(); err != nil {
+				b.warn("gi18n: reload failed, keeping previous translations", "dir", dir, "error", err)
+			}
+		}
+	}()
+	return nil
+}
+
+// scanDir 获取目录下语言文件的状态快照
+func scanDir(dir string) (map[string]fileStamp, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("gi18n: failed to read directory %s: %w", dir, err)
+	}
+
+	stamps := make(map[string]fileStamp, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !isSupportedExt(strings.ToLower(filepath.Ext(entry.Name()))) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			// 文件在扫描过程中被删除，视为不存在
+			continue
+		}
+		stamps[entry.Name()] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+	}
+	return stamps, nil
+}
+
+// sameStamps 判断两次快照是否一致
+func sameStamps(a, b map[string]fileStamp) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, sa := range a {
+		sb, ok := b[name]
+		if !ok || !sa.modTime.Equal(sb.modTime) || sa.size != sb.size {
+			return false
+		}
+	}
+	return true
+}
+
+// warn 通过 Logger 输出警告
+func (b *Bundle) warn(msg string, args ...any) {
+	if b.logger != nil {
+		b.logger.Warn(msg, args...)
+	}
+}
+
+// ========== 全局函数 ==========
+
+// Watch 监听目录并自动重新加载（全局）
+func Watch(ctx context.Context, dir string) error {
+	return Default().Watch(ctx, dir)
+}